@@ -5,13 +5,33 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/spf13/cobra"
 	"log"
 	"os"
+	"path/filepath"
 )
 
 type ImportOptions struct {
-	TemplateFile string
+	TemplateSourceOptions
+	OriginalStackName string
+	TagFilters        map[string]string
+	OutputFormat      string
+	Parameters        []string
+	IgnoreErrors      bool
+	OutputDir         string
+	TemplateOutput    string
+	ResourcesOutput   string
+	ReportOutput      string
+	Force             bool
+}
+
+// ImportBundle is written to stdout when the output directory is "-".
+type ImportBundle struct {
+	Format            string                     `json:"Format"`
+	Template          string                     `json:"Template"`
+	ResourcesToImport []cftypes.ResourceToImport `json:"ResourcesToImport"`
+	Report            *ImportReport              `json:"Report"`
 }
 
 var importOptions = &ImportOptions{}
@@ -27,35 +47,136 @@ var importCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(importCmd)
 
-	importCmd.Flags().StringVar(&importOptions.TemplateFile, "cf-template", "", "CloudFormation template file")
+	addTemplateSourceFlags(importCmd, &importOptions.TemplateSourceOptions)
+	importCmd.Flags().StringVar(&importOptions.OriginalStackName, "original-stack-name", "", "Name of the stack that created the resources, used to find them by tag, defaults to the stack of --stack-name or --stack-set-name")
+	importCmd.Flags().StringToStringVar(&importOptions.TagFilters, "tag-filter", nil, "Tags used to find resources without a name (key=value)")
+	importCmd.Flags().StringVar(&importOptions.OutputFormat, "output-format", "", "Format of the import template (yaml or json), defaults to the format of the input")
+	importCmd.Flags().StringArrayVar(&importOptions.Parameters, "parameters", nil, "Template parameter values as Key=Value or file://path to a parameters file")
+	importCmd.Flags().BoolVar(&importOptions.IgnoreErrors, "ignore-errors", false, "Create the import template without the resources that could not be looked up")
+	importCmd.Flags().StringVar(&importOptions.OutputDir, "output-dir", ".", "Directory to write the output files to, or - to write a single JSON bundle to stdout")
+	importCmd.Flags().StringVar(&importOptions.TemplateOutput, "template-output", "", "Import template file name, defaults to cloudformation_template.<format>")
+	importCmd.Flags().StringVar(&importOptions.ResourcesOutput, "resources-output", "ResourcesToImport.txt", "Resources to import file name")
+	importCmd.Flags().StringVar(&importOptions.ReportOutput, "report-output", "ImportReport.json", "Import report file name")
+	importCmd.Flags().BoolVar(&importOptions.Force, "force", false, "Overwrite an existing template and resources file")
 }
 
 func createImportTemplate(ctx context.Context) {
-	data, err := os.ReadFile(importOptions.TemplateFile)
+	source, err := loadTemplateSource(ctx, &importOptions.TemplateSourceOptions)
 	if err != nil {
 		log.Fatal(err)
 	}
+	data := source.Template
+
+	format := importOptions.OutputFormat
+	if format == "" {
+		format = template_parser.DetectTemplateFormat(data)
+	}
+	if format != template_parser.FormatYAML && format != template_parser.FormatJSON {
+		log.Fatalf("unknown output format %q, must be yaml or json", format)
+	}
 
-	cfi := &template_parser.CFImport{}
+	templateOutput := importOptions.TemplateOutput
+	if templateOutput == "" {
+		templateOutput = "cloudformation_template." + format
+	}
+	templatePath := outputPath(templateOutput)
+	resourcesPath := outputPath(importOptions.ResourcesOutput)
+	reportPath := outputPath(importOptions.ReportOutput)
+	// The report is written even when the lookup fails, so it is replaced
+	// on every run and only the import files are protected from overwrites.
+	if !importOptions.Force && importOptions.OutputDir != "-" {
+		for _, path := range []string{templatePath, resourcesPath} {
+			if _, err := os.Stat(path); err == nil {
+				log.Fatalf("%s already exists, use --force to overwrite it", path)
+			}
+		}
+	}
+
+	cfi, _, err := newTemplateImport(source, importOptions.OriginalStackName, importOptions.TagFilters, importOptions.Parameters)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cfi.OutputFormat = format
 
-	yamlData, importResources, err := cfi.ParseCloudFormationImportTemplate(ctx, data)
+	result, err := cfi.ParseCloudFormationImportTemplate(ctx, data)
 	if err != nil {
 		log.Fatal(err)
 		return
 	}
 
-	err = os.WriteFile("cloudformation_template.yaml", yamlData, 0644)
+	report := newImportReport(result.Results)
+	if importOptions.OutputDir == "-" {
+		writeImportBundle(result, report, format)
+		return
+	}
+	if err := os.MkdirAll(importOptions.OutputDir, 0755); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := report.WriteText(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+	reportData, err := report.JSON()
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = os.WriteFile(reportPath, reportData, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	checkImportErrors(result)
+
+	err = os.WriteFile(templatePath, result.Template, 0644)
 	if err != nil {
 		log.Fatal(err)
 	}
-	output, err := json.Marshal(importResources)
+	output, err := json.Marshal(result.ResourcesToImport)
 	if err != nil {
 		log.Fatal(err)
 	}
-	err = os.WriteFile("ResourcesToImport.txt", output, 0644)
+	err = os.WriteFile(resourcesPath, output, 0644)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	fmt.Println("Import templates successfully created")
 }
+
+// outputPath places relative output file names in the output directory.
+func outputPath(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(importOptions.OutputDir, name)
+}
+
+func checkImportErrors(result *template_parser.ImportResult) {
+	if err := result.Err(); err != nil && !importOptions.IgnoreErrors {
+		log.Fatalf("%v\nuse --ignore-errors to create the import template without these resources", err)
+	}
+}
+
+// writeImportBundle writes the template, resources to import and report to
+// stdout as one JSON document. The text report goes to stderr so stdout can
+// be piped.
+func writeImportBundle(result *template_parser.ImportResult, report *ImportReport, format string) {
+	if err := report.WriteText(os.Stderr); err != nil {
+		log.Fatal(err)
+	}
+	checkImportErrors(result)
+
+	output, err := json.MarshalIndent(&ImportBundle{
+		Format:            format,
+		Template:          string(result.Template),
+		ResourcesToImport: result.ResourcesToImport,
+		Report:            report,
+	}, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	output = append(output, '\n')
+	if _, err := os.Stdout.Write(output); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -24,6 +24,7 @@ type FixStackSetOptions struct {
 	StackSetName string
 	RoleName     string
 	S3Bucket     string
+	Parameters   []string
 }
 
 var fixStackSetOptions = &FixStackSetOptions{}
@@ -42,6 +43,7 @@ func init() {
 	fixStackSetCmd.Flags().StringVar(&fixStackSetOptions.StackSetName, "stack-set-name", "", "StackSet Name")
 	fixStackSetCmd.Flags().StringVar(&fixStackSetOptions.RoleName, "role-name", "", "Role name to assume into each account")
 	fixStackSetCmd.Flags().StringVar(&fixStackSetOptions.S3Bucket, "s3-bucket", "", "Bucket to place templates")
+	fixStackSetCmd.Flags().StringArrayVar(&fixStackSetOptions.Parameters, "parameters", nil, "Template parameter values as Key=Value or file://path to a parameters file, overriding those of every stack instance")
 }
 
 func fixStackSet(ctx context.Context) {
@@ -50,10 +52,15 @@ func fixStackSet(ctx context.Context) {
 		return
 	}
 
-	parseFailedStackSetInstances(ctx, fixStackSetOptions.StackSetName, fixStackSetOptions.RoleName, fixStackSetOptions.S3Bucket)
+	parameterValues, err := parseParameterValues(fixStackSetOptions.Parameters)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	parseFailedStackSetInstances(ctx, fixStackSetOptions.StackSetName, fixStackSetOptions.RoleName, fixStackSetOptions.S3Bucket, parameterValues)
 }
 
-func parseFailedStackSetInstances(ctx context.Context, stackSetName string, roleName string, bucketName string) {
+func parseFailedStackSetInstances(ctx context.Context, stackSetName string, roleName string, bucketName string, parameterValues map[string]string) {
 	cfg, err := config.LoadDefaultConfig(ctx)
 	if err != nil {
 		log.Fatalf("unable to load AWS SDK config, %v", err)
@@ -74,6 +81,7 @@ func parseFailedStackSetInstances(ctx context.Context, stackSetName string, role
 		log.Fatal(err)
 	}
 
+	failed := 0
 	var nextToken *string
 	for {
 		instances, err := cfn.ListStackInstances(ctx, &cloudformation.ListStackInstancesInput{
@@ -86,72 +94,117 @@ func parseFailedStackSetInstances(ctx context.Context, stackSetName string, role
 
 		for _, instance := range instances.Summaries {
 			if instance.StackInstanceStatus.DetailedStatus == cftypes.StackInstanceDetailedStatusFailed {
-				assumedCfg, err := assumeRole(ctx, cfg, aws.ToString(instance.Region), aws.ToString(instance.Account), roleName)
+				err := fixStackInstance(ctx, cfg, cfn, stackSetName, roleName, bucketName, stackSetDetails, templateUrl, instance, parameterValues)
 				if err != nil {
-					log.Fatal(err)
+					log.Printf("Skipping stack instance in account %s, region %s: %v", aws.ToString(instance.Account), aws.ToString(instance.Region), err)
+					failed++
+					continue
 				}
-				assumedCfn := cloudformation.NewFromConfig(assumedCfg)
 
-				cfi := &template_parser.CFImport{
-					Config: &assumedCfg,
-				}
+				fmt.Println("Stack instance successfully imported")
+			}
+		}
 
-				importTemplate, resourcesToImport, err := cfi.ParseCloudFormationImportTemplate(ctx, data)
-				if err != nil {
-					log.Fatal(err)
-				}
+		if instances.NextToken == nil {
+			break
+		}
+		nextToken = instances.NextToken
+	}
 
-				importData := []byte(importTemplate)
-				importTemplateName, _ := randomFilename(32)
-				importTemplateUrl, err := uploadS3File(ctx, cfg, bucketName, importTemplateName, importData)
-				if err != nil {
-					log.Fatal(err)
-				}
+	if failed > 0 {
+		log.Fatalf("%d stack instances could not be fixed", failed)
+	}
+}
 
-				log.Println("Importing Stack from StackSet template...")
-				stackName := extractStackName(*instance.StackId)
-				stackId, err := importStack(ctx, assumedCfn, stackName, "ImportChangeSet", importTemplateUrl, resourcesToImport)
-				if err != nil {
-					log.Fatal(err)
-				}
+// fixStackInstance imports the resources of a failed stack instance into a
+// new stack and moves that stack into the StackSet. Errors are returned so
+// that the remaining instances can still be fixed.
+func fixStackInstance(ctx context.Context, cfg aws.Config, cfn *cloudformation.Client, stackSetName, roleName, bucketName string, stackSetDetails *StackSetDetails, templateUrl string, instance cftypes.StackInstanceSummary, parameterValues map[string]string) error {
+	account := aws.ToString(instance.Account)
+	region := aws.ToString(instance.Region)
 
-				log.Println("Waiting for import to finish...")
-				err = waitForImport(ctx, assumedCfn, stackName)
-				if err != nil {
-					log.Fatal(err)
-				}
+	assumedCfg, err := assumeRole(ctx, cfg, region, account, roleName)
+	if err != nil {
+		return err
+	}
+	assumedCfn := cloudformation.NewFromConfig(assumedCfg)
+	stackName := extractStackName(*instance.StackId)
 
-				log.Println("Updating the Stack...")
-				err = updateStack(ctx, assumedCfn, stackName, templateUrl, stackSetDetails.Tags)
-				if err != nil {
-					log.Fatal(err)
-				}
+	stackInstance, err := getStackInstance(ctx, cfn, stackSetName, account, region)
+	if err != nil {
+		return fmt.Errorf("failed to describe stack instance: %w", err)
+	}
+	data := []byte(stackSetDetails.TemplateBody)
+	parameters, err := stackInstanceParameters(data, stackSetDetails.Parameters, stackInstance)
+	if err != nil {
+		return err
+	}
+	parameters.override(parameterValues)
+	// The stack is created anew, so masked values cannot be reused.
+	if err := parameters.checkMasked(nil); err != nil {
+		return err
+	}
 
-				log.Println("Deleting Stack from StackSet instances...")
-				err = deleteStackInstanceFromStackSet(ctx, cfn, stackSetName, aws.ToString(instance.Account), aws.ToString(instance.Region))
-				if err != nil {
-					log.Fatal(err)
-				}
+	cfi := &template_parser.CFImport{
+		Config:     &assumedCfg,
+		StackName:  stackName,
+		Parameters: parameters.Resolved,
+	}
 
-				log.Println("Importing the Stack to the StackSet instances...")
-				err = importStackToStackSet(ctx, cfn, stackSetName, aws.ToString(stackId))
-				if err != nil {
-					log.Fatal(err)
-				}
+	result, err := cfi.ParseCloudFormationImportTemplate(ctx, data)
+	if err != nil {
+		return err
+	}
+	if err := result.Err(); err != nil {
+		return err
+	}
 
-				fmt.Println("Stack instance successfully imported")
-			}
-		}
+	importParameters, err := stackParameters(result.Template, parameters.Values, nil)
+	if err != nil {
+		return err
+	}
+	importTemplateName, _ := randomFilename(32)
+	importTemplateUrl, err := uploadS3File(ctx, cfg, bucketName, importTemplateName, result.Template)
+	if err != nil {
+		return err
+	}
 
-		if instances.NextToken == nil {
-			break
-		}
-		nextToken = instances.NextToken
+	log.Println("Importing Stack from StackSet template...")
+	stackId, err := importStack(ctx, assumedCfn, stackName, "ImportChangeSet", importTemplateUrl, importParameters, result.ResourcesToImport)
+	if err != nil {
+		return err
 	}
+
+	log.Println("Waiting for import to finish...")
+	err = waitForImport(ctx, assumedCfn, stackName)
+	if err != nil {
+		return err
+	}
+
+	updateParameters, err := stackParameters(data, parameters.Values, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Println("Updating the Stack...")
+	err = updateStack(ctx, assumedCfn, stackName, templateUrl, updateParameters, stackSetDetails.Tags)
+	if err != nil {
+		return err
+	}
+
+	log.Println("Deleting Stack from StackSet instances...")
+	err = deleteStackInstanceFromStackSet(ctx, cfn, stackSetName, account, region)
+	if err != nil {
+		return err
+	}
+
+	log.Println("Importing the Stack to the StackSet instances...")
+	return importStackToStackSet(ctx, cfn, stackSetName, aws.ToString(stackId))
 }
 
 type StackSetDetails struct {
 	TemplateBody string
+	Parameters   []cftypes.Parameter
 	Tags         []cftypes.Tag
 }
 
@@ -166,6 +219,7 @@ func getStackSetDetails(ctx context.Context, cfn *cloudformation.Client, stackSe
 	if out != nil {
 		return &StackSetDetails{
 			TemplateBody: *out.StackSet.TemplateBody,
+			Parameters:   out.StackSet.Parameters,
 			Tags:         out.StackSet.Tags,
 		}, nil
 	}
@@ -173,10 +227,11 @@ func getStackSetDetails(ctx context.Context, cfn *cloudformation.Client, stackSe
 	return nil, errors.New("stack set not found")
 }
 
-func updateStack(ctx context.Context, cfn *cloudformation.Client, stackName, templateUrl string, tags []cftypes.Tag) error {
+func updateStack(ctx context.Context, cfn *cloudformation.Client, stackName, templateUrl string, parameters []cftypes.Parameter, tags []cftypes.Tag) error {
 	input := &cloudformation.UpdateStackInput{
 		StackName:   aws.String(stackName),
 		TemplateURL: aws.String(templateUrl),
+		Parameters:  parameters,
 		Tags:        tags,
 		Capabilities: []cftypes.Capability{
 			cftypes.CapabilityCapabilityNamedIam,
@@ -196,7 +251,18 @@ func updateStack(ctx context.Context, cfn *cloudformation.Client, stackName, tem
 	return err
 }
 
-func importStack(ctx context.Context, cfn *cloudformation.Client, stackName, changeSetName, templateUrl string, resourcesToImport []cftypes.ResourceToImport) (*string, error) {
+func importStack(ctx context.Context, cfn *cloudformation.Client, stackName, changeSetName, templateUrl string, parameters []cftypes.Parameter, resourcesToImport []cftypes.ResourceToImport) (*string, error) {
+	stackId, err := createImportChangeSet(ctx, cfn, stackName, changeSetName, templateUrl, parameters, resourcesToImport)
+	if err != nil {
+		return stackId, err
+	}
+
+	return stackId, executeChangeSet(ctx, cfn, stackName, changeSetName)
+}
+
+// createImportChangeSet creates an IMPORT change set, for a new stack or an
+// existing one, and waits until it is ready to be executed.
+func createImportChangeSet(ctx context.Context, cfn *cloudformation.Client, stackName, changeSetName, templateUrl string, parameters []cftypes.Parameter, resourcesToImport []cftypes.ResourceToImport) (*string, error) {
 	input := &cloudformation.CreateChangeSetInput{
 		ChangeSetName: aws.String(changeSetName),
 		StackName:     aws.String(stackName),
@@ -205,6 +271,7 @@ func importStack(ctx context.Context, cfn *cloudformation.Client, stackName, cha
 		},
 		ChangeSetType:     cftypes.ChangeSetTypeImport,
 		TemplateURL:       aws.String(templateUrl),
+		Parameters:        parameters,
 		ResourcesToImport: resourcesToImport,
 	}
 	output, err := cfn.CreateChangeSet(ctx, input)
@@ -223,18 +290,54 @@ func importStack(ctx context.Context, cfn *cloudformation.Client, stackName, cha
 		5*time.Minute, // max wait time
 	)
 	if err != nil {
-		return output.StackId, err
+		// The waiter only reports that the change set failed, the reason is
+		// on the change set itself.
+		described, describeErr := cfn.DescribeChangeSet(ctx, &cloudformation.DescribeChangeSetInput{
+			StackName:     aws.String(stackName),
+			ChangeSetName: aws.String(changeSetName),
+		})
+		if describeErr == nil && described.StatusReason != nil {
+			err = fmt.Errorf("%w: %s", err, aws.ToString(described.StatusReason))
+		}
 	}
+	return output.StackId, err
+}
 
-	_, err = cfn.ExecuteChangeSet(ctx, &cloudformation.ExecuteChangeSetInput{
+func describeChangeSet(ctx context.Context, cfn *cloudformation.Client, stackName, changeSetName string) ([]cftypes.Change, error) {
+	var changes []cftypes.Change
+	var nextToken *string
+	for {
+		output, err := cfn.DescribeChangeSet(ctx, &cloudformation.DescribeChangeSetInput{
+			StackName:     aws.String(stackName),
+			ChangeSetName: aws.String(changeSetName),
+			NextToken:     nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, output.Changes...)
+
+		if output.NextToken == nil {
+			return changes, nil
+		}
+		nextToken = output.NextToken
+	}
+}
+
+func deleteChangeSet(ctx context.Context, cfn *cloudformation.Client, stackName, changeSetName string) error {
+	_, err := cfn.DeleteChangeSet(ctx, &cloudformation.DeleteChangeSetInput{
 		StackName:     aws.String(stackName),
 		ChangeSetName: aws.String(changeSetName),
 	})
-	if err != nil {
-		return output.StackId, err
-	}
+	return err
+}
 
-	return output.StackId, nil
+func executeChangeSet(ctx context.Context, cfn *cloudformation.Client, stackName, changeSetName string) error {
+	_, err := cfn.ExecuteChangeSet(ctx, &cloudformation.ExecuteChangeSetInput{
+		StackName:     aws.String(stackName),
+		ChangeSetName: aws.String(changeSetName),
+	})
+	return err
 }
 
 func importStackToStackSet(ctx context.Context, cfn *cloudformation.Client, stackSetName, stackId string) error {
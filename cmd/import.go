@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/smithy-go"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+type ImportStackOptions struct {
+	TemplateSourceOptions
+	ImportStackName   string
+	ChangeSetName     string
+	S3Bucket          string
+	OriginalStackName string
+	TagFilters        map[string]string
+	Parameters        []string
+	IgnoreErrors      bool
+	Yes               bool
+}
+
+var importStackOptions = &ImportStackOptions{}
+
+var importStackCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import existing resources into a new or existing stack",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := importResources(cmd.Context())
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importStackCmd)
+
+	addTemplateSourceFlags(importStackCmd, &importStackOptions.TemplateSourceOptions)
+	importStackCmd.Flags().StringVar(&importStackOptions.ImportStackName, "import-stack-name", "", "Name of the stack to import the resources into")
+	importStackCmd.Flags().StringVar(&importStackOptions.ChangeSetName, "change-set-name", "ImportChangeSet", "Name of the import change set")
+	importStackCmd.Flags().StringVar(&importStackOptions.S3Bucket, "s3-bucket", "", "Bucket to place the import template")
+	importStackCmd.Flags().StringVar(&importStackOptions.OriginalStackName, "original-stack-name", "", "Name of the stack that created the resources, used to find them by tag, defaults to the stack of --stack-name or --stack-set-name")
+	importStackCmd.Flags().StringToStringVar(&importStackOptions.TagFilters, "tag-filter", nil, "Tags used to find resources without a name (key=value)")
+	importStackCmd.Flags().StringArrayVar(&importStackOptions.Parameters, "parameters", nil, "Template parameter values as Key=Value or file://path to a parameters file")
+	importStackCmd.Flags().BoolVar(&importStackOptions.IgnoreErrors, "ignore-errors", false, "Import without the resources that could not be looked up")
+	importStackCmd.Flags().BoolVar(&importStackOptions.Yes, "yes", false, "Execute the change set without asking for confirmation")
+}
+
+func importResources(ctx context.Context) error {
+	if importStackOptions.ImportStackName == "" {
+		return errors.New("you must specify --import-stack-name")
+	}
+	if importStackOptions.S3Bucket == "" {
+		return errors.New("you must specify --s3-bucket to upload the import template to")
+	}
+
+	source, err := loadTemplateSource(ctx, &importStackOptions.TemplateSourceOptions)
+	if err != nil {
+		return err
+	}
+
+	cfi, parameterValues, err := newTemplateImport(source, importStackOptions.OriginalStackName, importStackOptions.TagFilters, importStackOptions.Parameters)
+	if err != nil {
+		return err
+	}
+
+	cfn := cloudformation.NewFromConfig(source.Config)
+	stackName := importStackOptions.ImportStackName
+	changeSetName := importStackOptions.ChangeSetName
+
+	stack, err := describeStack(ctx, cfn, stackName)
+	if err != nil {
+		return err
+	}
+	// A stack in REVIEW_IN_PROGRESS only holds change sets that were never
+	// executed, so it is imported into like a new stack.
+	// Parameters of an existing stack keep their value, which also covers
+	// NoEcho parameters whose value cannot be read back.
+	var previousParameters map[string]bool
+	if stack != nil && stack.StackStatus != cftypes.StackStatusReviewInProgress {
+		log.Printf("Importing into existing stack %s", stackName)
+		var existingParameters *StackParameters
+		cfi.ExistingTemplate, existingParameters, err = getStackTemplate(ctx, cfn, stackName)
+		if err != nil {
+			return err
+		}
+		for key, value := range existingParameters.Resolved {
+			if _, ok := cfi.Parameters[key]; !ok {
+				cfi.Parameters[key] = value
+			}
+		}
+		for key := range existingParameters.Masked {
+			if _, ok := cfi.Parameters[key]; !ok {
+				cfi.UnknownParameters[key] = true
+			}
+		}
+		previousParameters = existingParameters.names()
+	}
+	if err := parameterValues.checkMasked(previousParameters); err != nil {
+		return err
+	}
+
+	result, err := cfi.ParseCloudFormationImportTemplate(ctx, source.Template)
+	if err != nil {
+		return err
+	}
+	if err := newImportReport(result.Results).WriteText(os.Stdout); err != nil {
+		return err
+	}
+	if err := result.Err(); err != nil && !importStackOptions.IgnoreErrors {
+		return fmt.Errorf("%w\nuse --ignore-errors to import without these resources", err)
+	}
+	if len(result.ResourcesToImport) == 0 {
+		return errors.New("no resources found to import")
+	}
+
+	parameters, err := stackParameters(result.Template, parameterValues.Values, previousParameters)
+	if err != nil {
+		return err
+	}
+
+	templateName, err := randomFilename(32)
+	if err != nil {
+		return err
+	}
+	templateUrl, err := uploadS3File(ctx, source.Config, importStackOptions.S3Bucket, templateName, result.Template)
+	if err != nil {
+		return err
+	}
+
+	log.Println("Creating import change set...")
+	stackId, err := createImportChangeSet(ctx, cfn, stackName, changeSetName, templateUrl, parameters, result.ResourcesToImport)
+	if err != nil {
+		// A change set that was created but failed keeps its name taken, so
+		// remove it to let the import be run again.
+		if stackId != nil {
+			removeChangeSet(ctx, cfn, stackName, changeSetName)
+		}
+		return fmt.Errorf("failed to create change set %s: %w", changeSetName, err)
+	}
+
+	if err := printChangeSet(ctx, cfn, os.Stdout, stackName, changeSetName); err != nil {
+		return err
+	}
+
+	if !importStackOptions.Yes {
+		ok, err := confirm(os.Stdin, os.Stdout, "Execute the change set?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			removeChangeSet(ctx, cfn, stackName, changeSetName)
+			fmt.Printf("Change set %s was not executed\n", changeSetName)
+			return nil
+		}
+	}
+
+	log.Println("Executing change set...")
+	err = executeChangeSet(ctx, cfn, stackName, changeSetName)
+	if err != nil {
+		return err
+	}
+
+	log.Println("Waiting for import to finish...")
+	err = waitForImport(ctx, cfn, stackName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Resources successfully imported")
+	return nil
+}
+
+// describeStack returns the stack with the given name, or nil if it does not
+// exist.
+func describeStack(ctx context.Context, cfn *cloudformation.Client, stackName string) (*cftypes.Stack, error) {
+	out, err := cfn.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" && strings.Contains(apiErr.ErrorMessage(), "does not exist") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to describe stack %s: %w", stackName, err)
+	}
+	if len(out.Stacks) == 0 {
+		return nil, nil
+	}
+
+	return &out.Stacks[0], nil
+}
+
+// removeChangeSet deletes a change set that will not be executed. Failing to
+// delete it is not fatal, so the user is told how to clean it up instead.
+func removeChangeSet(ctx context.Context, cfn *cloudformation.Client, stackName, changeSetName string) {
+	if err := deleteChangeSet(ctx, cfn, stackName, changeSetName); err != nil {
+		log.Printf("Failed to delete change set %s: %v", changeSetName, err)
+		log.Printf("Delete it with: aws cloudformation delete-change-set --stack-name %s --change-set-name %s", stackName, changeSetName)
+		return
+	}
+	log.Printf("Deleted change set %s", changeSetName)
+}
+
+func printChangeSet(ctx context.Context, cfn *cloudformation.Client, w io.Writer, stackName, changeSetName string) error {
+	changes, err := describeChangeSet(ctx, cfn, stackName, changeSetName)
+	if err != nil {
+		return fmt.Errorf("failed to describe change set %s: %w", changeSetName, err)
+	}
+
+	fmt.Fprintf(w, "\nChange set %s for stack %s:\n", changeSetName, stackName)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACTION\tLOGICAL ID\tTYPE\tPHYSICAL ID")
+	for _, change := range changes {
+		rc := change.ResourceChange
+		if rc == nil {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", rc.Action, aws.ToString(rc.LogicalResourceId), aws.ToString(rc.ResourceType), aws.ToString(rc.PhysicalResourceId))
+	}
+	return tw.Flush()
+}
+
+func confirm(r io.Reader, w io.Writer, question string) (bool, error) {
+	fmt.Fprintf(w, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"bytes"
+	"cfimporter/internal/template_parser"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"gopkg.in/yaml.v3"
+	"os"
+	"sort"
+	"strings"
+)
+
+type ParameterFileEntry struct {
+	ParameterKey   string      `json:"ParameterKey" yaml:"ParameterKey"`
+	ParameterValue interface{} `json:"ParameterValue" yaml:"ParameterValue"`
+}
+
+// parseParameterValues parses --parameters values. Each value is either
+// Key=Value or file://path to a JSON or YAML parameters file in the format
+// used by the CloudFormation CLI. Later values override earlier ones.
+func parseParameterValues(values []string) (map[string]string, error) {
+	parameters := make(map[string]string)
+
+	for _, value := range values {
+		if path, ok := strings.CutPrefix(value, "file://"); ok {
+			fileParameters, err := readParameterFile(path)
+			if err != nil {
+				return nil, err
+			}
+			for key, v := range fileParameters {
+				parameters[key] = v
+			}
+			continue
+		}
+
+		key, v, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid parameter %q, expected Key=Value or file://path", value)
+		}
+		parameters[key] = v
+	}
+
+	return parameters, nil
+}
+
+func readParameterFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ParameterFileEntry
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err = json.Unmarshal(data, &entries)
+	} else {
+		err = yaml.Unmarshal(data, &entries)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse parameters file %s: %w", path, err)
+	}
+
+	parameters := make(map[string]string)
+	for _, entry := range entries {
+		if entry.ParameterKey == "" {
+			return nil, fmt.Errorf("parameters file %s has an entry without ParameterKey", path)
+		}
+		parameters[entry.ParameterKey] = fmt.Sprint(entry.ParameterValue)
+	}
+
+	return parameters, nil
+}
+
+func getStackInstance(ctx context.Context, cfn *cloudformation.Client, stackSetName, account, region string) (*cftypes.StackInstance, error) {
+	out, err := cfn.DescribeStackInstance(ctx, &cloudformation.DescribeStackInstanceInput{
+		StackSetName:         aws.String(stackSetName),
+		StackInstanceAccount: aws.String(account),
+		StackInstanceRegion:  aws.String(region),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.StackInstance, nil
+}
+
+// maskedParameterValue is returned by CloudFormation in place of the value
+// of a NoEcho parameter.
+const maskedParameterValue = "****"
+
+// StackParameters are the parameter values a stack or stack instance was
+// deployed with. Values are passed to CloudFormation as is, while Resolved
+// holds what they evaluate to: for SSM parameter types the value the SSM
+// parameter resolved to rather than its name. SSM parameters without a
+// resolved value are left out of Resolved. CloudFormation does not return
+// the values of NoEcho parameters, so those are kept apart in Masked.
+type StackParameters struct {
+	Values   map[string]string
+	Resolved map[string]string
+	Masked   map[string]bool
+	ssm      map[string]bool
+}
+
+// newStackParameters returns the parameter values for a template, applying
+// the lists of parameters in order.
+func newStackParameters(templateBody []byte, parameters ...[]cftypes.Parameter) (*StackParameters, error) {
+	parameterTypes, err := template_parser.TemplateParameterTypes(templateBody)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &StackParameters{
+		Values:   make(map[string]string),
+		Resolved: make(map[string]string),
+		Masked:   make(map[string]bool),
+		ssm:      make(map[string]bool),
+	}
+	for name, parameterType := range parameterTypes {
+		if template_parser.IsSSMParameterType(parameterType) {
+			sp.ssm[name] = true
+		}
+	}
+	for _, list := range parameters {
+		for _, p := range list {
+			sp.set(p)
+		}
+	}
+
+	return sp, nil
+}
+
+func (sp *StackParameters) set(p cftypes.Parameter) {
+	key := aws.ToString(p.ParameterKey)
+	value := aws.ToString(p.ParameterValue)
+	if value == maskedParameterValue {
+		delete(sp.Values, key)
+		delete(sp.Resolved, key)
+		sp.Masked[key] = true
+		return
+	}
+
+	delete(sp.Masked, key)
+	sp.Values[key] = value
+	sp.setResolved(key, value)
+	if p.ResolvedValue != nil {
+		sp.Resolved[key] = aws.ToString(p.ResolvedValue)
+	}
+}
+
+// setResolved records the value a parameter evaluates to, unless it is the
+// name of an SSM parameter.
+func (sp *StackParameters) setResolved(key, value string) {
+	if sp.ssm[key] {
+		delete(sp.Resolved, key)
+		return
+	}
+	sp.Resolved[key] = value
+}
+
+// override sets parameter values given by the user, which take precedence
+// over the deployed ones.
+func (sp *StackParameters) override(values map[string]string) {
+	for key, value := range values {
+		delete(sp.Masked, key)
+		sp.Values[key] = value
+		sp.setResolved(key, value)
+	}
+}
+
+// names returns every parameter with a value, masked or not.
+func (sp *StackParameters) names() map[string]bool {
+	names := make(map[string]bool)
+	for key := range sp.Values {
+		names[key] = true
+	}
+	for key := range sp.Masked {
+		names[key] = true
+	}
+
+	return names
+}
+
+// checkMasked returns an error naming the NoEcho parameters whose value is
+// unknown, or nil if there are none. Parameters in previous are ignored, as
+// the stack keeps their value. It is only needed before deploying: reading
+// a template leaves masked parameters unresolved instead.
+func (sp *StackParameters) checkMasked(previous map[string]bool) error {
+	var names []string
+	for key := range sp.Masked {
+		if !previous[key] {
+			names = append(names, key)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	return fmt.Errorf("the values of NoEcho parameters %s cannot be read from CloudFormation, pass them with --parameters", strings.Join(names, ", "))
+}
+
+// stackInstanceParameters returns the parameter values of a stack instance:
+// the StackSet values merged with the instance's overrides.
+func stackInstanceParameters(templateBody []byte, stackSetParameters []cftypes.Parameter, instance *cftypes.StackInstance) (*StackParameters, error) {
+	return newStackParameters(templateBody, stackSetParameters, instance.ParameterOverrides)
+}
+
+// stackParameters converts parameter values to CloudFormation parameters,
+// keeping only those declared by the template. Parameters in previous keep
+// the value the stack was deployed with.
+func stackParameters(templateBody []byte, values map[string]string, previous map[string]bool) ([]cftypes.Parameter, error) {
+	names, err := template_parser.TemplateParameterNames(templateBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var parameters []cftypes.Parameter
+	for _, name := range names {
+		if previous[name] {
+			parameters = append(parameters, cftypes.Parameter{
+				ParameterKey:     aws.String(name),
+				UsePreviousValue: aws.Bool(true),
+			})
+			continue
+		}
+		if value, ok := values[name]; ok {
+			parameters = append(parameters, cftypes.Parameter{
+				ParameterKey:   aws.String(name),
+				ParameterValue: aws.String(value),
+			})
+		}
+	}
+
+	return parameters, nil
+}
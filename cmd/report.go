@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"cfimporter/internal/template_parser"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// ImportReport is the machine readable report of create-import-template.
+type ImportReport struct {
+	Summary   map[template_parser.ResourceStatus]int `json:"Summary"`
+	Resources []template_parser.ResourceResult       `json:"Resources"`
+}
+
+func newImportReport(results []template_parser.ResourceResult) *ImportReport {
+	report := &ImportReport{
+		Summary:   make(map[template_parser.ResourceStatus]int),
+		Resources: results,
+	}
+	for _, result := range results {
+		report.Summary[result.Status]++
+	}
+	return report
+}
+
+func (r *ImportReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// WriteText writes the report as a table with one row per template
+// resource, followed by a summary line.
+func (r *ImportReport) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LOGICAL ID\tTYPE\tSTATUS\tDETAILS")
+	for _, result := range r.Resources {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.LogicalId, result.ResourceType, statusText(result.Status), resultDetails(result))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d to import, %d to create, %d unsupported, %d skipped, %d errors\n",
+		r.Summary[template_parser.ResourceFound],
+		r.Summary[template_parser.ResourceNotFound],
+		r.Summary[template_parser.ResourceUnsupported],
+		r.Summary[template_parser.ResourceSkipped],
+		r.Summary[template_parser.ResourceError])
+	return err
+}
+
+func statusText(status template_parser.ResourceStatus) string {
+	switch status {
+	case template_parser.ResourceFound:
+		return "import"
+	case template_parser.ResourceNotFound:
+		return "create"
+	}
+	return string(status)
+}
+
+func resultDetails(result template_parser.ResourceResult) string {
+	switch result.Status {
+	case template_parser.ResourceFound:
+		keys := make([]string, 0, len(result.Identifier))
+		for key := range result.Identifier {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		identifier := make([]string, len(keys))
+		for i, key := range keys {
+			identifier[i] = key + "=" + result.Identifier[key]
+		}
+		return strings.Join(identifier, ", ")
+	case template_parser.ResourceNotFound:
+		return "not found, will be created by CloudFormation"
+	}
+	return result.Reason
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"cfimporter/internal/template_parser"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/spf13/cobra"
+	"maps"
+	"os"
+	"strings"
+)
+
+type TemplateSourceOptions struct {
+	TemplateFile string
+	StackName    string
+	StackSetName string
+	Account      string
+	Region       string
+	RoleName     string
+}
+
+func addTemplateSourceFlags(cmd *cobra.Command, opts *TemplateSourceOptions) {
+	cmd.Flags().StringVar(&opts.TemplateFile, "cf-template", "", "CloudFormation template file")
+	cmd.Flags().StringVar(&opts.StackName, "stack-name", "", "Read the original template of a deployed stack, by name or stack ID")
+	cmd.Flags().StringVar(&opts.StackSetName, "stack-set-name", "", "Read the template of a StackSet, together with the parameters of its stack instance")
+	cmd.Flags().StringVar(&opts.Account, "account", "", "Account to import the resources into")
+	cmd.Flags().StringVar(&opts.Region, "region", "", "Region to import the resources into")
+	cmd.Flags().StringVar(&opts.RoleName, "role-name", "", "Role name to assume into --account")
+}
+
+// TemplateSource is a template together with the account and region its
+// resources are imported into. StackName and Parameters are taken from the
+// deployed stack or stack instance the template was read from.
+type TemplateSource struct {
+	Template   []byte
+	Config     aws.Config
+	StackName  string
+	Parameters *StackParameters
+}
+
+// loadTemplateSource reads the template from a local file, a deployed stack
+// or a StackSet. The StackSet is read with the default credentials, while
+// stacks and resources are read from the target account.
+func loadTemplateSource(ctx context.Context, opts *TemplateSourceOptions) (*TemplateSource, error) {
+	sources := 0
+	for _, source := range []string{opts.TemplateFile, opts.StackName, opts.StackSetName} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, errors.New("specify exactly one of --cf-template, --stack-name or --stack-set-name")
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS SDK config, %w", err)
+	}
+
+	targetCfg, err := targetConfig(ctx, cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &TemplateSource{
+		Config: targetCfg,
+	}
+
+	switch {
+	case opts.TemplateFile != "":
+		source.Template, err = os.ReadFile(opts.TemplateFile)
+		if err != nil {
+			return nil, err
+		}
+		source.Parameters, err = newStackParameters(source.Template)
+		if err != nil {
+			return nil, err
+		}
+	case opts.StackName != "":
+		cfn := cloudformation.NewFromConfig(targetCfg)
+		source.Template, source.Parameters, err = getStackTemplate(ctx, cfn, opts.StackName)
+		if err != nil {
+			return nil, err
+		}
+		source.StackName = opts.StackName
+		if strings.HasPrefix(opts.StackName, "arn:") {
+			source.StackName = extractStackName(opts.StackName)
+		}
+	case opts.StackSetName != "":
+		if opts.Account == "" {
+			return nil, errors.New("--account is required with --stack-set-name")
+		}
+
+		cfn := cloudformation.NewFromConfig(cfg)
+		stackSetDetails, err := getStackSetDetails(ctx, cfn, opts.StackSetName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get stack set template, %w", err)
+		}
+		stackInstance, err := getStackInstance(ctx, cfn, opts.StackSetName, opts.Account, targetCfg.Region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe stack instance: %w", err)
+		}
+
+		source.Template = []byte(stackSetDetails.TemplateBody)
+		source.Parameters, err = stackInstanceParameters(source.Template, stackSetDetails.Parameters, stackInstance)
+		if err != nil {
+			return nil, err
+		}
+		source.StackName = extractStackName(aws.ToString(stackInstance.StackId))
+	}
+
+	return source, nil
+}
+
+// newTemplateImport creates the CFImport for a template source. Values given
+// with --parameters override the ones of the deployed stack, and the source
+// stack is used to find resources by tag unless originalStackName is set.
+// It also returns the parameter values to create the stack with.
+func newTemplateImport(source *TemplateSource, originalStackName string, tagFilters map[string]string, parameterValues []string) (*template_parser.CFImport, *StackParameters, error) {
+	values, err := parseParameterValues(parameterValues)
+	if err != nil {
+		return nil, nil, err
+	}
+	parameters := source.Parameters
+	parameters.override(values)
+
+	stackName := originalStackName
+	if stackName == "" {
+		stackName = source.StackName
+	}
+
+	return &template_parser.CFImport{
+		Config:     &source.Config,
+		StackName:  stackName,
+		TagFilters: tagFilters,
+		Parameters: parameters.Resolved,
+		// Masked values must not fall back to the parameter defaults.
+		UnknownParameters: maps.Clone(parameters.Masked),
+	}, parameters, nil
+}
+
+// targetConfig returns the config for the account and region resources are
+// imported into, assuming --role-name when another account is given.
+func targetConfig(ctx context.Context, cfg aws.Config, opts *TemplateSourceOptions) (aws.Config, error) {
+	region := cfg.Region
+	if opts.Region != "" {
+		region = opts.Region
+	}
+
+	if opts.Account == "" {
+		if opts.RoleName != "" {
+			return aws.Config{}, errors.New("--role-name requires --account")
+		}
+		targetCfg := cfg.Copy()
+		targetCfg.Region = region
+		return targetCfg, nil
+	}
+	if opts.RoleName == "" {
+		return aws.Config{}, errors.New("--account requires --role-name to assume into the account")
+	}
+
+	return assumeRole(ctx, cfg, region, opts.Account, opts.RoleName)
+}
+
+// getStackTemplate returns the original template of a deployed stack and
+// the parameter values it was deployed with. A deleted stack can be read by
+// passing its stack ID.
+func getStackTemplate(ctx context.Context, cfn *cloudformation.Client, stackName string) ([]byte, *StackParameters, error) {
+	template, err := cfn.GetTemplate(ctx, &cloudformation.GetTemplateInput{
+		StackName:     aws.String(stackName),
+		TemplateStage: cftypes.TemplateStageOriginal,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get template of stack %s: %w", stackName, err)
+	}
+
+	stacks, err := cfn.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to describe stack %s: %w", stackName, err)
+	}
+
+	body := []byte(aws.ToString(template.TemplateBody))
+	var deployed [][]cftypes.Parameter
+	for _, stack := range stacks.Stacks {
+		deployed = append(deployed, stack.Parameters)
+	}
+	parameters, err := newStackParameters(body, deployed...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse template of stack %s: %w", stackName, err)
+	}
+
+	return body, parameters, nil
+}
@@ -0,0 +1,47 @@
+package aws_cloudcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+)
+
+// ErrReadNotSupported is returned when a resource type has no read handler
+// and therefore cannot be looked up through Cloud Control.
+var ErrReadNotSupported = errors.New("resource type does not support read")
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createCloudControlClient(_ context.Context, cfg aws.Config) *cloudcontrol.Client {
+	cccClient := cloudcontrol.NewFromConfig(cfg)
+	return cccClient
+}
+
+// GetResourceIdentifier returns the primary identifier of an existing
+// resource, or nil when no resource with that identifier exists.
+func (awsClient *AWSClient) GetResourceIdentifier(ctx context.Context, typeName, identifier string) (*string, error) {
+	client := createCloudControlClient(ctx, awsClient.Config)
+	output, err := client.GetResource(ctx, &cloudcontrol.GetResourceInput{
+		TypeName:   aws.String(typeName),
+		Identifier: aws.String(identifier),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		var unsupported *types.UnsupportedActionException
+		if errors.As(err, &unsupported) {
+			return nil, ErrReadNotSupported
+		}
+		return nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+
+	return output.ResourceDescription.Identifier, nil
+}
@@ -0,0 +1,46 @@
+package aws_cloudformation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/smithy-go"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createCloudFormationClient(_ context.Context, cfg aws.Config) *cloudformation.Client {
+	cfnClient := cloudformation.NewFromConfig(cfg)
+	return cfnClient
+}
+
+// GetResourceTypeSchema returns the registry schema of a resource type, or nil
+// when the type is not known to the CloudFormation registry. Type names the
+// registry cannot hold, such as Custom::Name, are rejected with a validation
+// error and count as unknown as well.
+func (awsClient *AWSClient) GetResourceTypeSchema(ctx context.Context, typeName string) (*string, error) {
+	client := createCloudFormationClient(ctx, awsClient.Config)
+	output, err := client.DescribeType(ctx, &cloudformation.DescribeTypeInput{
+		Type:     types.RegistryTypeResource,
+		TypeName: aws.String(typeName),
+	})
+	if err != nil {
+		var notFound *types.TypeNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to describe type: %w", err)
+	}
+
+	return output.Schema, nil
+}
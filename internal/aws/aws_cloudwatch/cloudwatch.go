@@ -0,0 +1,63 @@
+package aws_cloudwatch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createCloudWatchClient(_ context.Context, cfg aws.Config) *cloudwatch.Client {
+	cloudWatchClient := cloudwatch.NewFromConfig(cfg)
+	return cloudWatchClient
+}
+
+func (awsClient *AWSClient) AlarmExists(ctx context.Context, alarmName string) (bool, error) {
+	client := createCloudWatchClient(ctx, awsClient.Config)
+	output, err := client.DescribeAlarms(ctx, &cloudwatch.DescribeAlarmsInput{
+		AlarmNames: []string{alarmName},
+		AlarmTypes: []types.AlarmType{
+			types.AlarmTypeMetricAlarm,
+			types.AlarmTypeCompositeAlarm,
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to describe alarms: %w", err)
+	}
+
+	return len(output.MetricAlarms) > 0 || len(output.CompositeAlarms) > 0, nil
+}
+
+func (awsClient *AWSClient) DashboardExists(ctx context.Context, dashboardName string) (bool, error) {
+	client := createCloudWatchClient(ctx, awsClient.Config)
+	var nextToken *string
+
+	for {
+		output, err := client.ListDashboards(ctx, &cloudwatch.ListDashboardsInput{
+			DashboardNamePrefix: aws.String(dashboardName),
+			NextToken:           nextToken,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to list dashboards: %w", err)
+		}
+
+		for _, dashboard := range output.DashboardEntries {
+			if aws.ToString(dashboard.DashboardName) == dashboardName {
+				return true, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	return false, nil
+}
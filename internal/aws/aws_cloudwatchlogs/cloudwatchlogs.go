@@ -0,0 +1,114 @@
+package aws_cloudwatchlogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createCloudWatchLogsClient(_ context.Context, cfg aws.Config) *cloudwatchlogs.Client {
+	logsClient := cloudwatchlogs.NewFromConfig(cfg)
+	return logsClient
+}
+
+func (awsClient *AWSClient) LogGroupExists(ctx context.Context, logGroupName string) (bool, error) {
+	client := createCloudWatchLogsClient(ctx, awsClient.Config)
+	var nextToken *string
+
+	for {
+		output, err := client.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+			LogGroupNamePrefix: aws.String(logGroupName),
+			NextToken:          nextToken,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to describe log groups: %w", err)
+		}
+
+		for _, logGroup := range output.LogGroups {
+			if aws.ToString(logGroup.LogGroupName) == logGroupName {
+				return true, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	return false, nil
+}
+
+func (awsClient *AWSClient) MetricFilterExists(ctx context.Context, logGroupName, filterName string) (bool, error) {
+	client := createCloudWatchLogsClient(ctx, awsClient.Config)
+	var nextToken *string
+
+	for {
+		output, err := client.DescribeMetricFilters(ctx, &cloudwatchlogs.DescribeMetricFiltersInput{
+			LogGroupName:     aws.String(logGroupName),
+			FilterNamePrefix: aws.String(filterName),
+			NextToken:        nextToken,
+		})
+		if err != nil {
+			var notFound *types.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to describe metric filters: %w", err)
+		}
+
+		for _, filter := range output.MetricFilters {
+			if aws.ToString(filter.FilterName) == filterName {
+				return true, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	return false, nil
+}
+
+func (awsClient *AWSClient) SubscriptionFilterExists(ctx context.Context, logGroupName, filterName string) (bool, error) {
+	client := createCloudWatchLogsClient(ctx, awsClient.Config)
+	var nextToken *string
+
+	for {
+		output, err := client.DescribeSubscriptionFilters(ctx, &cloudwatchlogs.DescribeSubscriptionFiltersInput{
+			LogGroupName:     aws.String(logGroupName),
+			FilterNamePrefix: aws.String(filterName),
+			NextToken:        nextToken,
+		})
+		if err != nil {
+			var notFound *types.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to describe subscription filters: %w", err)
+		}
+
+		for _, filter := range output.SubscriptionFilters {
+			if aws.ToString(filter.FilterName) == filterName {
+				return true, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	return false, nil
+}
@@ -0,0 +1,36 @@
+package aws_dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createDynamoDBClient(_ context.Context, cfg aws.Config) *dynamodb.Client {
+	dynamoDBClient := dynamodb.NewFromConfig(cfg)
+	return dynamoDBClient
+}
+
+func (awsClient *AWSClient) GetTableName(ctx context.Context, tableName string) (*string, error) {
+	client := createDynamoDBClient(ctx, awsClient.Config)
+	output, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to describe table: %w", err)
+	}
+
+	return output.Table.TableName, nil
+}
@@ -0,0 +1,66 @@
+package aws_ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createEC2Client(_ context.Context, cfg aws.Config) *ec2.Client {
+	ec2Client := ec2.NewFromConfig(cfg)
+	return ec2Client
+}
+
+// FindResourceIdsByTags returns the IDs of the resources of an EC2 resource
+// type (vpc, subnet, security-group, ...) that carry all the given tags.
+func (awsClient *AWSClient) FindResourceIdsByTags(ctx context.Context, resourceType string, tags map[string]string) ([]string, error) {
+	client := createEC2Client(ctx, awsClient.Config)
+	var matches map[string]bool
+
+	for key, value := range tags {
+		tagged := make(map[string]bool)
+		var nextToken *string
+
+		for {
+			output, err := client.DescribeTags(ctx, &ec2.DescribeTagsInput{
+				Filters: []types.Filter{
+					{Name: aws.String("resource-type"), Values: []string{resourceType}},
+					{Name: aws.String("key"), Values: []string{key}},
+					{Name: aws.String("value"), Values: []string{value}},
+				},
+				NextToken: nextToken,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe tags: %w", err)
+			}
+
+			for _, tag := range output.Tags {
+				id := aws.ToString(tag.ResourceId)
+				if matches == nil || matches[id] {
+					tagged[id] = true
+				}
+			}
+
+			if output.NextToken == nil {
+				break
+			}
+			nextToken = output.NextToken
+		}
+
+		matches = tagged
+	}
+
+	var ids []string
+	for id := range matches {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,43 @@
+package aws_eventbridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createEventBridgeClient(_ context.Context, cfg aws.Config) *eventbridge.Client {
+	eventBridgeClient := eventbridge.NewFromConfig(cfg)
+	return eventBridgeClient
+}
+
+// GetRuleArn returns the ARN of a rule. An empty eventBusName refers to the
+// default event bus.
+func (awsClient *AWSClient) GetRuleArn(ctx context.Context, ruleName, eventBusName string) (*string, error) {
+	client := createEventBridgeClient(ctx, awsClient.Config)
+	input := &eventbridge.DescribeRuleInput{
+		Name: aws.String(ruleName),
+	}
+	if eventBusName != "" {
+		input.EventBusName = aws.String(eventBusName)
+	}
+
+	output, err := client.DescribeRule(ctx, input)
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to describe rule: %w", err)
+	}
+
+	return output.Arn, nil
+}
@@ -0,0 +1,48 @@
+package aws_kms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createKMSClient(_ context.Context, cfg aws.Config) *kms.Client {
+	kmsClient := kms.NewFromConfig(cfg)
+	return kmsClient
+}
+
+// FindAliasTargetKeyId returns the ID of the key an alias points to, or nil
+// when the alias does not exist.
+func (awsClient *AWSClient) FindAliasTargetKeyId(ctx context.Context, aliasName string) (*string, error) {
+	client := createKMSClient(ctx, awsClient.Config)
+	var marker *string
+
+	for {
+		output, err := client.ListAliases(ctx, &kms.ListAliasesInput{
+			Marker: marker,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list aliases: %w", err)
+		}
+
+		for _, alias := range output.Aliases {
+			if aws.ToString(alias.AliasName) == aliasName {
+				return alias.TargetKeyId, nil
+			}
+		}
+
+		if output.Truncated {
+			marker = output.NextMarker
+		} else {
+			break
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,71 @@
+package aws_lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createLambdaClient(_ context.Context, cfg aws.Config) *lambda.Client {
+	lambdaClient := lambda.NewFromConfig(cfg)
+	return lambdaClient
+}
+
+func (awsClient *AWSClient) GetFunctionName(ctx context.Context, functionName string) (*string, error) {
+	client := createLambdaClient(ctx, awsClient.Config)
+	output, err := client.GetFunction(ctx, &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get function: %w", err)
+	}
+
+	return output.Configuration.FunctionName, nil
+}
+
+func (awsClient *AWSClient) GetAliasArn(ctx context.Context, functionName, aliasName string) (*string, error) {
+	client := createLambdaClient(ctx, awsClient.Config)
+	output, err := client.GetAlias(ctx, &lambda.GetAliasInput{
+		FunctionName: aws.String(functionName),
+		Name:         aws.String(aliasName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get alias: %w", err)
+	}
+
+	return output.AliasArn, nil
+}
+
+// GetFunctionPolicy returns the resource-based policy document of a function,
+// or nil when the function does not exist or has no policy.
+func (awsClient *AWSClient) GetFunctionPolicy(ctx context.Context, functionName string) (*string, error) {
+	client := createLambdaClient(ctx, awsClient.Config)
+	output, err := client.GetPolicy(ctx, &lambda.GetPolicyInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get function policy: %w", err)
+	}
+
+	return output.Policy, nil
+}
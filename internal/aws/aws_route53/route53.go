@@ -0,0 +1,86 @@
+package aws_route53
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createRoute53Client(_ context.Context, cfg aws.Config) *route53.Client {
+	route53Client := route53.NewFromConfig(cfg)
+	return route53Client
+}
+
+// FindHostedZoneId returns the ID, without the /hostedzone/ prefix, of the
+// public or private hosted zone for a domain name.
+func (awsClient *AWSClient) FindHostedZoneId(ctx context.Context, zoneName string, private bool) (*string, error) {
+	client := createRoute53Client(ctx, awsClient.Config)
+	zoneName = fqdn(zoneName)
+
+	input := &route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(zoneName),
+	}
+	for {
+		output, err := client.ListHostedZonesByName(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list hosted zones: %w", err)
+		}
+
+		for _, zone := range output.HostedZones {
+			if !strings.EqualFold(aws.ToString(zone.Name), zoneName) {
+				// Zones are sorted by name, so there are no further matches.
+				return nil, nil
+			}
+			if zone.Config != nil && zone.Config.PrivateZone == private {
+				id := strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/")
+				return &id, nil
+			}
+		}
+
+		if !output.IsTruncated {
+			break
+		}
+		input.DNSName = output.NextDNSName
+		input.HostedZoneId = output.NextHostedZoneId
+	}
+
+	return nil, nil
+}
+
+func (awsClient *AWSClient) RecordSetExists(ctx context.Context, zoneId, recordName, recordType string) (bool, error) {
+	client := createRoute53Client(ctx, awsClient.Config)
+	recordName = fqdn(recordName)
+
+	output, err := client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneId),
+		StartRecordName: aws.String(recordName),
+		StartRecordType: types.RRType(recordType),
+		MaxItems:        aws.Int32(1),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list resource record sets: %w", err)
+	}
+
+	for _, record := range output.ResourceRecordSets {
+		if strings.EqualFold(aws.ToString(record.Name), recordName) && string(record.Type) == recordType {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
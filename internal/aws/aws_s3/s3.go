@@ -0,0 +1,53 @@
+package aws_s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createS3Client(_ context.Context, cfg aws.Config) *s3.Client {
+	s3Client := s3.NewFromConfig(cfg)
+	return s3Client
+}
+
+func (awsClient *AWSClient) BucketExists(ctx context.Context, bucketName string) (bool, error) {
+	client := createS3Client(ctx, awsClient.Config)
+	_, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to head bucket: %w", err)
+	}
+
+	return true, nil
+}
+
+func (awsClient *AWSClient) BucketPolicyExists(ctx context.Context, bucketName string) (bool, error) {
+	client := createS3Client(ctx, awsClient.Config)
+	_, err := client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NoSuchBucketPolicy" || apiErr.ErrorCode() == "NoSuchBucket") {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get bucket policy: %w", err)
+	}
+
+	return true, nil
+}
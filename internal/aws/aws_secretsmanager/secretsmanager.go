@@ -0,0 +1,42 @@
+package aws_secretsmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createSecretsManagerClient(_ context.Context, cfg aws.Config) *secretsmanager.Client {
+	secretsManagerClient := secretsmanager.NewFromConfig(cfg)
+	return secretsManagerClient
+}
+
+// GetSecretArn returns the full ARN, including the random suffix, of the
+// secret with the given name.
+func (awsClient *AWSClient) GetSecretArn(ctx context.Context, secretName string) (*string, error) {
+	client := createSecretsManagerClient(ctx, awsClient.Config)
+	output, err := client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(secretName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to describe secret: %w", err)
+	}
+
+	if output.DeletedDate != nil {
+		return nil, fmt.Errorf("secret %s is scheduled for deletion", secretName)
+	}
+
+	return output.ARN, nil
+}
@@ -0,0 +1,80 @@
+package aws_sns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createSNSClient(_ context.Context, cfg aws.Config) *sns.Client {
+	snsClient := sns.NewFromConfig(cfg)
+	return snsClient
+}
+
+func (awsClient *AWSClient) FindTopicArnByName(ctx context.Context, topicName string) (*string, error) {
+	client := createSNSClient(ctx, awsClient.Config)
+	var nextToken *string
+
+	for {
+		output, err := client.ListTopics(ctx, &sns.ListTopicsInput{
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list topics: %w", err)
+		}
+
+		for _, topic := range output.Topics {
+			if strings.HasSuffix(aws.ToString(topic.TopicArn), ":"+topicName) {
+				return topic.TopicArn, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	return nil, nil
+}
+
+// FindSubscriptionArn returns the ARN of the confirmed subscription of a topic
+// that delivers to the given protocol and endpoint.
+func (awsClient *AWSClient) FindSubscriptionArn(ctx context.Context, topicArn, protocol, endpoint string) (*string, error) {
+	client := createSNSClient(ctx, awsClient.Config)
+	var nextToken *string
+
+	for {
+		output, err := client.ListSubscriptionsByTopic(ctx, &sns.ListSubscriptionsByTopicInput{
+			TopicArn:  aws.String(topicArn),
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+
+		for _, subscription := range output.Subscriptions {
+			if !strings.HasPrefix(aws.ToString(subscription.SubscriptionArn), "arn:") {
+				// PendingConfirmation and Deleted subscriptions have no ARN yet.
+				continue
+			}
+			if strings.EqualFold(protocol, aws.ToString(subscription.Protocol)) && endpoint == aws.ToString(subscription.Endpoint) {
+				return subscription.SubscriptionArn, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	return nil, nil
+}
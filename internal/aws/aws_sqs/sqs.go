@@ -0,0 +1,36 @@
+package aws_sqs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createSQSClient(_ context.Context, cfg aws.Config) *sqs.Client {
+	sqsClient := sqs.NewFromConfig(cfg)
+	return sqsClient
+}
+
+func (awsClient *AWSClient) GetQueueUrl(ctx context.Context, queueName string) (*string, error) {
+	client := createSQSClient(ctx, awsClient.Config)
+	output, err := client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+		QueueName: aws.String(queueName),
+	})
+	if err != nil {
+		var notFound *types.QueueDoesNotExist
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get queue url: %w", err)
+	}
+
+	return output.QueueUrl, nil
+}
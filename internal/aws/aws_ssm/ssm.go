@@ -0,0 +1,36 @@
+package aws_ssm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createSSMClient(_ context.Context, cfg aws.Config) *ssm.Client {
+	ssmClient := ssm.NewFromConfig(cfg)
+	return ssmClient
+}
+
+func (awsClient *AWSClient) GetParameterName(ctx context.Context, parameterName string) (*string, error) {
+	client := createSSMClient(ctx, awsClient.Config)
+	output, err := client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name: aws.String(parameterName),
+	})
+	if err != nil {
+		var notFound *types.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	return output.Parameter.Name, nil
+}
@@ -0,0 +1,28 @@
+package aws_sts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+type AWSClient struct {
+	Config aws.Config
+}
+
+func createSTSClient(_ context.Context, cfg aws.Config) *sts.Client {
+	stsClient := sts.NewFromConfig(cfg)
+	return stsClient
+}
+
+func (awsClient *AWSClient) GetAccountId(ctx context.Context) (string, error) {
+	client := createSTSClient(ctx, awsClient.Config)
+	output, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get caller identity: %w", err)
+	}
+
+	return aws.ToString(output.Account), nil
+}
@@ -0,0 +1,133 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_cloudcontrol"
+	"cfimporter/internal/aws/aws_cloudformation"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// fallbackResolver is used for every resource type without a registered
+// resolver. It derives the primary identifier from the resource type schema
+// and confirms the resource exists through Cloud Control.
+var fallbackResolver = parserMethod(newCloudControlParser, (*CloudControlParser).parseResource)
+
+type resourceTypeSchema struct {
+	PrimaryIdentifier  []string `json:"primaryIdentifier"`
+	ReadOnlyProperties []string `json:"readOnlyProperties"`
+}
+
+var schemaCache = struct {
+	sync.Mutex
+	schemas map[string]*resourceTypeSchema
+}{schemas: make(map[string]*resourceTypeSchema)}
+
+type CloudControlParser struct {
+	CloudFormationClient *aws_cloudformation.AWSClient
+	CloudControlClient   *aws_cloudcontrol.AWSClient
+	region               string
+}
+
+func newCloudControlParser(cfg aws.Config) *CloudControlParser {
+	return &CloudControlParser{
+		CloudFormationClient: &aws_cloudformation.AWSClient{
+			Config: cfg,
+		},
+		CloudControlClient: &aws_cloudcontrol.AWSClient{
+			Config: cfg,
+		},
+		region: cfg.Region,
+	}
+}
+
+func (cp *CloudControlParser) parseResource(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	resourceType := req.Resource.Type
+	// Custom resources only exist as calls to their provider, so there is
+	// nothing in the account to import.
+	if strings.HasPrefix(resourceType, "Custom::") || resourceType == "AWS::CloudFormation::CustomResource" {
+		return nil, fmt.Errorf("%w: %s is a custom resource", ErrUnsupportedResourceType, resourceType)
+	}
+
+	schema, err := cp.resourceTypeSchema(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("%w: %s is not in the CloudFormation registry", ErrUnsupportedResourceType, resourceType)
+	}
+	if len(schema.PrimaryIdentifier) == 0 {
+		return nil, fmt.Errorf("%w: %s has no primary identifier", ErrUnsupportedResourceType, resourceType)
+	}
+
+	identifier := make(map[string]string)
+	var values []string
+	for _, path := range schema.PrimaryIdentifier {
+		if slices.Contains(schema.ReadOnlyProperties, path) {
+			return nil, fmt.Errorf("%w: %s is generated by AWS for %s", ErrUnsupportedResourceType, path, resourceType)
+		}
+
+		name, ok := strings.CutPrefix(path, "/properties/")
+		if !ok || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("%w: cannot derive %s for %s", ErrUnsupportedResourceType, path, resourceType)
+		}
+
+		value, ok := req.Resource.Properties[name].(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not set in the template", ErrUnsupportedResourceType, name)
+		}
+
+		identifier[name] = value
+		values = append(values, value)
+	}
+
+	id, err := cp.CloudControlClient.GetResourceIdentifier(ctx, resourceType, strings.Join(values, "|"))
+	if errors.Is(err, aws_cloudcontrol.ErrReadNotSupported) {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedResourceType, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, identifier), nil
+}
+
+// resourceTypeSchema fetches the registry schema for a resource type. Schemas
+// are cached per region because they are requested once per resource.
+func (cp *CloudControlParser) resourceTypeSchema(ctx context.Context, resourceType string) (*resourceTypeSchema, error) {
+	key := cp.region + "/" + resourceType
+
+	schemaCache.Lock()
+	schema, ok := schemaCache.schemas[key]
+	schemaCache.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	document, err := cp.CloudFormationClient.GetResourceTypeSchema(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if document != nil {
+		schema = &resourceTypeSchema{}
+		if err := json.Unmarshal([]byte(*document), schema); err != nil {
+			return nil, fmt.Errorf("failed to parse schema for %s: %w", resourceType, err)
+		}
+	}
+
+	schemaCache.Lock()
+	schemaCache.schemas[key] = schema
+	schemaCache.Unlock()
+
+	return schema, nil
+}
@@ -0,0 +1,63 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_cloudwatch"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::CloudWatch::Alarm", parserMethod(newCloudWatchParser, (*CloudWatchParser).parseAlarm))
+	RegisterResolver("AWS::CloudWatch::Dashboard", parserMethod(newCloudWatchParser, (*CloudWatchParser).parseDashboard))
+}
+
+type CloudWatchParser struct {
+	CloudWatchClient *aws_cloudwatch.AWSClient
+}
+
+func newCloudWatchParser(cfg aws.Config) *CloudWatchParser {
+	return &CloudWatchParser{
+		CloudWatchClient: &aws_cloudwatch.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (cp *CloudWatchParser) parseAlarm(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	alarmName, ok, err := stringProperty(req.Resource, "AlarmName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	exists, err := cp.CloudWatchClient.AlarmExists(ctx, alarmName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"AlarmName": alarmName,
+	}), nil
+}
+
+func (cp *CloudWatchParser) parseDashboard(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	dashboardName, ok, err := stringProperty(req.Resource, "DashboardName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	exists, err := cp.CloudWatchClient.DashboardExists(ctx, dashboardName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"DashboardName": dashboardName,
+	}), nil
+}
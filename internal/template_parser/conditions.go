@@ -0,0 +1,135 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"context"
+	"fmt"
+)
+
+// Enabled reports whether a resource is created in the target account and
+// region. Resources whose condition cannot be evaluated are treated as
+// enabled.
+func (ev *Evaluator) Enabled(ctx context.Context, resource types.Resource) (bool, error) {
+	if resource.Condition == "" {
+		return true, nil
+	}
+
+	value, known, err := ev.condition(ctx, resource.Condition)
+	if err != nil {
+		return false, err
+	}
+
+	return value || !known, nil
+}
+
+// condition evaluates a named condition from the Conditions section. The
+// second result is false when the condition depends on values that cannot
+// be resolved before the stack exists.
+func (ev *Evaluator) condition(ctx context.Context, name string) (bool, bool, error) {
+	if value, ok := ev.conditions[name]; ok {
+		return value, true, nil
+	}
+
+	expression, ok := ev.Template.Conditions[name]
+	if !ok {
+		return false, false, fmt.Errorf("condition %s is not defined", name)
+	}
+	if ev.evaluating[name] {
+		return false, false, fmt.Errorf("condition %s references itself", name)
+	}
+
+	ev.evaluating[name] = true
+	defer delete(ev.evaluating, name)
+
+	value, known, err := ev.conditionValue(ctx, expression)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to evaluate condition %s: %w", name, err)
+	}
+	if known {
+		ev.conditions[name] = value
+	}
+
+	return value, known, nil
+}
+
+func (ev *Evaluator) conditionValue(ctx context.Context, expression any) (bool, bool, error) {
+	if b, ok := expression.(bool); ok {
+		return b, true, nil
+	}
+
+	m, ok := expression.(map[string]any)
+	if !ok || len(m) != 1 {
+		return false, false, nil
+	}
+
+	for name, args := range m {
+		switch name {
+		case "Condition":
+			conditionName, ok := args.(string)
+			if !ok {
+				return false, false, nil
+			}
+			return ev.condition(ctx, conditionName)
+		case "Fn::Equals":
+			return ev.equals(ctx, args)
+		case "Fn::Not":
+			a, ok := args.([]any)
+			if !ok || len(a) != 1 {
+				return false, false, nil
+			}
+			value, known, err := ev.conditionValue(ctx, a[0])
+			return !value, known, err
+		case "Fn::And":
+			return ev.combine(ctx, args, false)
+		case "Fn::Or":
+			return ev.combine(ctx, args, true)
+		}
+	}
+
+	return false, false, nil
+}
+
+func (ev *Evaluator) equals(ctx context.Context, args any) (bool, bool, error) {
+	a, ok := args.([]any)
+	if !ok || len(a) != 2 {
+		return false, false, nil
+	}
+
+	var values [2]string
+	for i, item := range a {
+		evaluated, ok, err := ev.evaluate(ctx, item)
+		if err != nil || !ok {
+			return false, false, err
+		}
+		s, ok := scalarString(evaluated)
+		if !ok {
+			return false, false, nil
+		}
+		values[i] = s
+	}
+
+	return values[0] == values[1], true, nil
+}
+
+// combine evaluates Fn::And and Fn::Or. A single operand equal to
+// shortCircuit decides the result even when other operands are unknown.
+func (ev *Evaluator) combine(ctx context.Context, args any, shortCircuit bool) (bool, bool, error) {
+	a, ok := args.([]any)
+	if !ok {
+		return false, false, nil
+	}
+
+	known := true
+	for _, item := range a {
+		value, ok, err := ev.conditionValue(ctx, item)
+		if err != nil {
+			return false, false, err
+		}
+		if ok && value == shortCircuit {
+			return shortCircuit, true, nil
+		}
+		known = known && ok
+	}
+
+	return !shortCircuit, known, nil
+}
@@ -0,0 +1,73 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"context"
+	"testing"
+)
+
+const conditionsTemplate = `
+Parameters:
+  Env:
+    Type: String
+    Default: prod
+  Unset:
+    Type: String
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  IsDev: !Equals [!Ref Env, dev]
+  NotProd: !Not [!Condition IsProd]
+  Unknown: !Equals [!Ref Unset, x]
+  ProdAndDev: !And [!Condition IsProd, !Condition IsDev]
+  ProdOrDev: !Or [!Condition IsProd, !Condition IsDev]
+  DevAndUnknown: !And [!Condition IsDev, !Condition Unknown]
+  ProdOrUnknown: !Or [!Condition IsProd, !Condition Unknown]
+  ProdAndUnknown: !And [!Condition IsProd, !Condition Unknown]
+  Loop: !Not [!Condition Loop]
+  InRegion: !Equals [!Ref AWS::Region, us-east-1]
+Resources: {}
+`
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		condition string
+		want      bool
+		wantErr   bool
+	}{
+		{condition: "", want: true},
+		{condition: "IsProd", want: true},
+		{condition: "IsDev", want: false},
+		{condition: "NotProd", want: false},
+		{condition: "InRegion", want: true},
+		{condition: "ProdAndDev", want: false},
+		{condition: "ProdOrDev", want: true},
+		// Unknown conditions keep the resource, unless another operand
+		// decides the result on its own.
+		{condition: "Unknown", want: true},
+		{condition: "DevAndUnknown", want: false},
+		{condition: "ProdOrUnknown", want: true},
+		{condition: "ProdAndUnknown", want: true},
+		{condition: "Loop", wantErr: true},
+		{condition: "Undefined", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			ev := newTestEvaluator(t, conditionsTemplate, nil)
+
+			got, err := ev.Enabled(context.Background(), types.Resource{Condition: tt.condition})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Enabled(%s) = %v, want error", tt.condition, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Enabled(%s) failed: %v", tt.condition, err)
+			}
+			if got != tt.want {
+				t.Errorf("Enabled(%s) = %v, want %v", tt.condition, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"sort"
+)
+
+// dependencyGraph records which resources each resource of a template
+// depends on. References made through Ref, Fn::GetAtt and Fn::Sub are kept
+// apart from DependsOn: a resource cannot be imported without the resources
+// it references, while DependsOn only orders operations and can be dropped.
+type dependencyGraph struct {
+	references map[string][]string
+	dependsOn  map[string][]string
+}
+
+func newDependencyGraph(template *types.CloudFormationTemplate) *dependencyGraph {
+	graph := &dependencyGraph{
+		references: make(map[string][]string),
+		dependsOn:  make(map[string][]string),
+	}
+
+	for name, resource := range template.Resources {
+		refs := newTemplateReferences()
+		refs.addResource(resource)
+		for target := range refs.names {
+			if _, ok := template.Resources[target]; ok && target != name {
+				graph.references[name] = append(graph.references[name], target)
+			}
+		}
+		sort.Strings(graph.references[name])
+
+		graph.dependsOn[name] = dependsOnNames(resource.DependsOn)
+	}
+
+	return graph
+}
+
+// missingReferences returns the resources referenced by a resource that are
+// neither part of included nor of existing.
+func (g *dependencyGraph) missingReferences(name string, included, existing map[string]types.Resource) []string {
+	var missing []string
+	for _, target := range g.references[name] {
+		if !containsResource(target, included, existing) {
+			missing = append(missing, target)
+		}
+	}
+	return missing
+}
+
+// removeUnresolvedDependents removes the resources that reference a resource
+// which is not included, repeating until every remaining reference can be
+// resolved. References to existing resources, which are already part of the
+// stack, are always resolved. It returns the missing references of every
+// removed resource.
+func (g *dependencyGraph) removeUnresolvedDependents(included, existing map[string]types.Resource) map[string][]string {
+	removed := make(map[string][]string)
+	for changed := true; changed; {
+		changed = false
+		for _, name := range sortedResourceNames(included) {
+			if missing := g.missingReferences(name, included, existing); len(missing) > 0 {
+				removed[name] = missing
+				delete(included, name)
+				changed = true
+			}
+		}
+	}
+	return removed
+}
+
+// stripDependsOn removes the DependsOn entries of a resource that point at
+// resources which are neither included nor existing.
+func (g *dependencyGraph) stripDependsOn(name string, resource types.Resource, included, existing map[string]types.Resource) types.Resource {
+	dependsOn := g.dependsOn[name]
+	if len(dependsOn) == 0 {
+		return resource
+	}
+
+	var kept []any
+	for _, target := range dependsOn {
+		if containsResource(target, included, existing) {
+			kept = append(kept, target)
+		}
+	}
+
+	switch {
+	case len(kept) == len(dependsOn):
+	case len(kept) == 0:
+		resource.DependsOn = nil
+	default:
+		resource.DependsOn = kept
+	}
+
+	return resource
+}
+
+func containsResource(name string, included, existing map[string]types.Resource) bool {
+	_, isIncluded := included[name]
+	_, isExisting := existing[name]
+	return isIncluded || isExisting
+}
+
+func dependsOnNames(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var names []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				names = append(names, s)
+			}
+		}
+		return names
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"reflect"
+	"testing"
+)
+
+const dependenciesTemplate = `
+Parameters:
+  Name:
+    Type: String
+Resources:
+  Key:
+    Type: AWS::KMS::Key
+  Queue:
+    Type: AWS::SQS::Queue
+    Properties:
+      QueueName: !Ref Name
+      KmsMasterKeyId: !GetAtt Key.Arn
+  Policy:
+    Type: AWS::SQS::QueuePolicy
+    Properties:
+      Queues: [!Ref Queue]
+  Alarm:
+    Type: AWS::CloudWatch::Alarm
+    DependsOn: [Queue, Topic]
+    Properties:
+      AlarmName: !Sub "${Policy}-alarm"
+      AlarmActions: [!Sub "${Topic.TopicArn}"]
+  Topic:
+    Type: AWS::SNS::Topic
+    DependsOn: Key
+`
+
+func newTestDependencyGraph(t *testing.T) (*dependencyGraph, *types.CloudFormationTemplate) {
+	t.Helper()
+
+	var template types.CloudFormationTemplate
+	if _, _, err := unmarshalTemplate([]byte(dependenciesTemplate), &template); err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	return newDependencyGraph(&template), &template
+}
+
+func resourceSet(names ...string) map[string]types.Resource {
+	resources := make(map[string]types.Resource)
+	for _, name := range names {
+		resources[name] = types.Resource{}
+	}
+	return resources
+}
+
+func TestDependencyGraphReferences(t *testing.T) {
+	graph, _ := newTestDependencyGraph(t)
+
+	tests := []struct {
+		resource string
+		want     []string
+	}{
+		{resource: "Key", want: nil},
+		// Parameters are not resources.
+		{resource: "Queue", want: []string{"Key"}},
+		{resource: "Policy", want: []string{"Queue"}},
+		// References through Fn::Sub count, DependsOn does not.
+		{resource: "Alarm", want: []string{"Policy", "Topic"}},
+		{resource: "Topic", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			if got := graph.references[tt.resource]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("references = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveUnresolvedDependents(t *testing.T) {
+	tests := []struct {
+		name        string
+		included    []string
+		existing    []string
+		wantKept    []string
+		wantRemoved map[string][]string
+	}{
+		{
+			name:        "everything included",
+			included:    []string{"Key", "Queue", "Policy", "Alarm", "Topic"},
+			wantKept:    []string{"Key", "Queue", "Policy", "Alarm", "Topic"},
+			wantRemoved: map[string][]string{},
+		},
+		{
+			name:     "removal is transitive",
+			included: []string{"Queue", "Policy", "Alarm", "Topic"},
+			wantKept: []string{"Topic"},
+			wantRemoved: map[string][]string{
+				"Queue":  {"Key"},
+				"Policy": {"Queue"},
+				"Alarm":  {"Policy"},
+			},
+		},
+		{
+			name:        "existing resources resolve references",
+			included:    []string{"Queue", "Policy"},
+			existing:    []string{"Key"},
+			wantKept:    []string{"Queue", "Policy"},
+			wantRemoved: map[string][]string{},
+		},
+		{
+			name:     "every missing reference is reported",
+			included: []string{"Alarm"},
+			wantKept: []string{},
+			wantRemoved: map[string][]string{
+				"Alarm": {"Policy", "Topic"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, _ := newTestDependencyGraph(t)
+			included := resourceSet(tt.included...)
+
+			removed := graph.removeUnresolvedDependents(included, resourceSet(tt.existing...))
+
+			if want := resourceSet(tt.wantKept...); !reflect.DeepEqual(included, want) {
+				t.Errorf("kept %v, want %v", sortedResourceNames(included), tt.wantKept)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removed %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestStripDependsOn(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		included []string
+		existing []string
+		want     any
+	}{
+		{name: "all kept", resource: "Alarm", included: []string{"Queue", "Topic"}, want: []any{"Queue", "Topic"}},
+		{name: "one removed", resource: "Alarm", included: []string{"Topic"}, want: []any{"Topic"}},
+		{name: "kept by existing", resource: "Alarm", existing: []string{"Queue"}, included: []string{"Topic"}, want: []any{"Queue", "Topic"}},
+		{name: "all removed", resource: "Alarm", want: nil},
+		{name: "string kept", resource: "Topic", included: []string{"Key"}, want: "Key"},
+		{name: "string removed", resource: "Topic", want: nil},
+		{name: "no depends on", resource: "Queue", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, template := newTestDependencyGraph(t)
+
+			got := graph.stripDependsOn(tt.resource, template.Resources[tt.resource], resourceSet(tt.included...), resourceSet(tt.existing...))
+			if !reflect.DeepEqual(got.DependsOn, tt.want) {
+				t.Errorf("DependsOn = %#v, want %#v", got.DependsOn, tt.want)
+			}
+		})
+	}
+}
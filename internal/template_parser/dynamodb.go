@@ -0,0 +1,46 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_dynamodb"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::DynamoDB::Table", parserMethod(newDynamoDBParser, (*DynamoDBParser).parseTable))
+	RegisterResolver("AWS::DynamoDB::GlobalTable", parserMethod(newDynamoDBParser, (*DynamoDBParser).parseTable))
+}
+
+type DynamoDBParser struct {
+	DynamoDBClient *aws_dynamodb.AWSClient
+}
+
+func newDynamoDBParser(cfg aws.Config) *DynamoDBParser {
+	return &DynamoDBParser{
+		DynamoDBClient: &aws_dynamodb.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+// parseTable resolves both regular and global tables, which share the
+// TableName identifier.
+func (dp *DynamoDBParser) parseTable(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	tableName, ok, err := stringProperty(req.Resource, "TableName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	name, err := dp.DynamoDBClient.GetTableName(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if name == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"TableName": *name,
+	}), nil
+}
@@ -0,0 +1,71 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_ec2"
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"sort"
+)
+
+func init() {
+	RegisterResolver("AWS::EC2::VPC", ec2Resolver("vpc", "VpcId"))
+	RegisterResolver("AWS::EC2::Subnet", ec2Resolver("subnet", "SubnetId"))
+	RegisterResolver("AWS::EC2::SecurityGroup", ec2Resolver("security-group", "Id"))
+	RegisterResolver("AWS::EC2::RouteTable", ec2Resolver("route-table", "RouteTableId"))
+	RegisterResolver("AWS::EC2::InternetGateway", ec2Resolver("internet-gateway", "InternetGatewayId"))
+}
+
+type EC2Parser struct {
+	EC2Client *aws_ec2.AWSClient
+}
+
+func newEC2Parser(cfg aws.Config) *EC2Parser {
+	return &EC2Parser{
+		EC2Client: &aws_ec2.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func ec2Resolver(resourceType, identifierKey string) ResourceResolver {
+	return parserMethod(newEC2Parser, func(ep *EC2Parser, ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+		return ep.parseTaggedResource(ctx, req, resourceType, identifierKey)
+	})
+}
+
+// parseTaggedResource locates networking resources, which have no name that
+// can be set in the template, through the tags CloudFormation put on them
+// when the original stack created them.
+func (ep *EC2Parser) parseTaggedResource(ctx context.Context, req *ResolveRequest, resourceType, identifierKey string) (*cftypes.ResourceToImport, error) {
+	if req.StackName == "" && len(req.TagFilters) == 0 {
+		return nil, fmt.Errorf("%w: a stack name or tag filter is needed to locate %s", ErrUnsupportedResourceType, req.Resource.Type)
+	}
+
+	tags := map[string]string{
+		"aws:cloudformation:logical-id": req.LogicalId,
+	}
+	if req.StackName != "" {
+		tags["aws:cloudformation:stack-name"] = req.StackName
+	}
+	for key, value := range req.TagFilters {
+		tags[key] = value
+	}
+
+	ids, err := ep.EC2Client.FindResourceIdsByTags(ctx, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > 1 {
+		sort.Strings(ids)
+		return nil, fmt.Errorf("found more than one %s tagged for %s: %v", resourceType, req.LogicalId, ids)
+	}
+
+	return newResourceToImport(req, map[string]string{
+		identifierKey: ids[0],
+	}), nil
+}
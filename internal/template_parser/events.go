@@ -0,0 +1,51 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_eventbridge"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::Events::Rule", parserMethod(newEventsParser, (*EventsParser).parseRule))
+}
+
+type EventsParser struct {
+	EventBridgeClient *aws_eventbridge.AWSClient
+}
+
+func newEventsParser(cfg aws.Config) *EventsParser {
+	return &EventsParser{
+		EventBridgeClient: &aws_eventbridge.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (ep *EventsParser) parseRule(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	ruleName, ok, err := stringProperty(req.Resource, "Name")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var eventBusName string
+	if value, ok := req.Resource.Properties["EventBusName"]; ok {
+		eventBusName, ok, err = refProperty(req.Template, value, "Name")
+		if err != nil || !ok {
+			return nil, err
+		}
+	}
+
+	arn, err := ep.EventBridgeClient.GetRuleArn(ctx, ruleName, eventBusName)
+	if err != nil {
+		return nil, err
+	}
+	if arn == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"Arn": *arn,
+	}), nil
+}
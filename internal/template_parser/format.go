@@ -0,0 +1,134 @@
+package template_parser
+
+import (
+	"bytes"
+	"cfimporter/internal/types"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"sort"
+	"strings"
+)
+
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// DetectTemplateFormat reports whether a template body is JSON or YAML.
+func DetectTemplateFormat(data []byte) string {
+	if bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n\ufeff"), []byte("{")) {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// unmarshalTemplate decodes a JSON or YAML template. It returns the format
+// of the template and whether it used YAML short-form tags.
+func unmarshalTemplate(data []byte, template *types.CloudFormationTemplate) (string, bool, error) {
+	format := DetectTemplateFormat(data)
+	if format == FormatJSON {
+		return format, false, unmarshalJSON(data, template)
+	}
+
+	shortForm, err := unmarshalYAML(data, template)
+	return format, shortForm, err
+}
+
+func marshalTemplate(template *types.CloudFormationTemplate, format string, shortForm bool) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(template); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatYAML:
+		return marshalYAML(template, shortForm)
+	}
+
+	return nil, fmt.Errorf("unknown template format %q", format)
+}
+
+// unmarshalJSON decodes a JSON template through a YAML node, so both formats
+// share the same struct tags and produce the same value types.
+func unmarshalJSON(data []byte, out any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := node.Encode(normalizeJSONNumbers(document)); err != nil {
+		return err
+	}
+
+	return node.Decode(out)
+}
+
+// normalizeJSONNumbers converts json.Number values to int or float64, the
+// types YAML decoding produces.
+func normalizeJSONNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeJSONNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeJSONNumbers(item)
+		}
+	}
+	return value
+}
+
+// TemplateParameterNames returns the names of the parameters a template
+// declares, in sorted order.
+func TemplateParameterNames(data []byte) ([]string, error) {
+	var template types.CloudFormationTemplate
+	if _, _, err := unmarshalTemplate(data, &template); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(template.Parameters))
+	for name := range template.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// TemplateParameterTypes returns the type of every parameter a template
+// declares.
+func TemplateParameterTypes(data []byte) (map[string]string, error) {
+	var template types.CloudFormationTemplate
+	if _, _, err := unmarshalTemplate(data, &template); err != nil {
+		return nil, err
+	}
+
+	parameterTypes := make(map[string]string, len(template.Parameters))
+	for name, parameter := range template.Parameters {
+		parameterTypes[name] = parameter.Type
+	}
+
+	return parameterTypes, nil
+}
+
+// IsSSMParameterType reports whether a parameter type takes the name of an
+// SSM parameter, which CloudFormation replaces with its value on deploy.
+func IsSSMParameterType(parameterType string) bool {
+	return strings.HasPrefix(parameterType, "AWS::SSM::Parameter::Value<")
+}
@@ -2,85 +2,82 @@ package template_parser
 
 import (
 	"cfimporter/internal/aws/aws_iam"
-	"cfimporter/internal/types"
 	"context"
-	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
-	"log"
 )
 
+func init() {
+	RegisterResolver("AWS::IAM::Role", parserMethod(newIAMParser, (*IAMParser).parseIAMRole))
+	RegisterResolver("AWS::IAM::ManagedPolicy", parserMethod(newIAMParser, (*IAMParser).parseIAMPolicy))
+	RegisterResolver("AWS::IAM::InstanceProfile", parserMethod(newIAMParser, (*IAMParser).parseInstanceProfile))
+}
+
 type IAMParser struct {
 	IAMClient *aws_iam.AWSClient
 }
 
-func (ip *IAMParser) parseIAMRole(ctx context.Context, resource types.Resource, resourceName string) *cftypes.ResourceToImport {
-	roleName := resource.Properties["RoleName"].(string)
+func newIAMParser(cfg aws.Config) *IAMParser {
+	return &IAMParser{
+		IAMClient: &aws_iam.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (ip *IAMParser) parseIAMRole(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	roleName, ok, err := stringProperty(req.Resource, "RoleName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
 	name, err := ip.IAMClient.GetIAMRoleName(ctx, roleName)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	if name == nil {
-		return nil
+		return nil, nil
 	}
 
-	fmt.Printf("IAM role name: %s\n", *name)
+	return newResourceToImport(req, map[string]string{
+		"RoleName": *name,
+	}), nil
+}
 
-	return &cftypes.ResourceToImport{
-		ResourceType:      aws.String(resource.Type),
-		LogicalResourceId: aws.String(resourceName),
-		ResourceIdentifier: map[string]string{
-			"RoleName": *name,
-		},
+func (ip *IAMParser) parseIAMPolicy(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	policyName, ok, err := stringProperty(req.Resource, "ManagedPolicyName")
+	if err != nil || !ok {
+		return nil, err
 	}
-}
 
-func (ip *IAMParser) parseIAMPolicy(ctx context.Context, resource types.Resource, resourceName string) *cftypes.ResourceToImport {
-	policyName := resource.Properties["ManagedPolicyName"].(string)
 	arn, err := ip.IAMClient.FindPolicyArnByName(ctx, policyName)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	if arn == nil {
-		return nil
+		return nil, nil
 	}
-	fmt.Printf("Policy ARN: %s\n", *arn)
 
-	return &cftypes.ResourceToImport{
-		ResourceType:      aws.String(resource.Type),
-		LogicalResourceId: aws.String(resourceName),
-		ResourceIdentifier: map[string]string{
-			"PolicyArn": *arn,
-		},
-	}
+	return newResourceToImport(req, map[string]string{
+		"PolicyArn": *arn,
+	}), nil
 }
 
-func (ip *IAMParser) parseInstanceProfile(ctx context.Context, resource types.Resource, resourceName string, resources map[string]types.Resource) *cftypes.ResourceToImport {
-	val := resource.Properties["InstanceProfileName"]
-	var profileName string
-
-	if m, ok := val.(map[string]any); ok {
-		roleRef := m["Ref"].(string)
-		role := resources[roleRef]
-		profileName = role.Properties["RoleName"].(string)
-	} else if s, ok := val.(string); ok {
-		profileName = s
+func (ip *IAMParser) parseInstanceProfile(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	profileName, ok, err := refProperty(req.Template, req.Resource.Properties["InstanceProfileName"], "RoleName")
+	if err != nil || !ok {
+		return nil, err
 	}
 
 	name, err := ip.IAMClient.GetIAMInstanceProfileName(ctx, profileName)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	if name == nil {
-		return nil
+		return nil, nil
 	}
-	fmt.Printf("Instance profile name: %s\n", *name)
 
-	return &cftypes.ResourceToImport{
-		ResourceType:      aws.String(resource.Type),
-		LogicalResourceId: aws.String(resourceName),
-		ResourceIdentifier: map[string]string{
-			"InstanceProfileName": *name,
-		},
-	}
+	return newResourceToImport(req, map[string]string{
+		"InstanceProfileName": *name,
+	}), nil
 }
@@ -0,0 +1,502 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_sts"
+	"cfimporter/internal/types"
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"strconv"
+	"strings"
+)
+
+// noValue is the result of a Ref to AWS::NoValue. Properties and list items
+// that evaluate to it are removed.
+var noValue = noValueType{}
+
+type noValueType struct{}
+
+// pseudoParameters resolves the CloudFormation pseudo parameters for the
+// account and region resources are imported into. The account ID is only
+// looked up when a template actually references it.
+type pseudoParameters struct {
+	STSClient *aws_sts.AWSClient
+	region    string
+	stackName string
+	accountId string
+}
+
+func newPseudoParameters(cfg aws.Config, stackName string) *pseudoParameters {
+	return &pseudoParameters{
+		STSClient: &aws_sts.AWSClient{
+			Config: cfg,
+		},
+		region:    cfg.Region,
+		stackName: stackName,
+	}
+}
+
+func (pp *pseudoParameters) lookup(ctx context.Context, name string) (string, bool, error) {
+	switch name {
+	case "AWS::AccountId":
+		if pp.accountId == "" {
+			accountId, err := pp.STSClient.GetAccountId(ctx)
+			if err != nil {
+				return "", false, err
+			}
+			pp.accountId = accountId
+		}
+		return pp.accountId, true, nil
+	case "AWS::Region":
+		return pp.region, true, nil
+	case "AWS::Partition":
+		return partition(pp.region), true, nil
+	case "AWS::URLSuffix":
+		if strings.HasPrefix(pp.region, "cn-") {
+			return "amazonaws.com.cn", true, nil
+		}
+		return "amazonaws.com", true, nil
+	case "AWS::StackName":
+		return pp.stackName, pp.stackName != "", nil
+	}
+
+	return "", false, nil
+}
+
+func partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	}
+	return "aws"
+}
+
+// Evaluator resolves intrinsic functions in template values against the
+// target account and region and the supplied parameter values. Anything
+// that depends on other resources, such as Fn::GetAtt, is left untouched.
+// Parameters in Unknown have a value that cannot be read and are never
+// resolved.
+type Evaluator struct {
+	Template   *types.CloudFormationTemplate
+	Parameters map[string]string
+	Unknown    map[string]bool
+	pseudo     *pseudoParameters
+	conditions map[string]bool
+	evaluating map[string]bool
+}
+
+func newEvaluator(template *types.CloudFormationTemplate, parameters map[string]string, pseudo *pseudoParameters) *Evaluator {
+	return &Evaluator{
+		Template:   template,
+		Parameters: parameters,
+		pseudo:     pseudo,
+		conditions: make(map[string]bool),
+		evaluating: make(map[string]bool),
+	}
+}
+
+// EvaluateTemplate returns a copy of the template with the properties of
+// every resource evaluated, so resolvers can read names as plain strings.
+// Resources whose condition is false are left out. A resource that fails to
+// evaluate is left out as well and its error is returned by logical ID, so
+// one bad resource does not stop the others from being imported.
+func (ev *Evaluator) EvaluateTemplate(ctx context.Context) (*types.CloudFormationTemplate, map[string]error) {
+	evaluated := *ev.Template
+	evaluated.Resources = make(map[string]types.Resource, len(ev.Template.Resources))
+	errs := make(map[string]error)
+
+	for name, resource := range ev.Template.Resources {
+		enabled, err := ev.Enabled(ctx, resource)
+		if err != nil {
+			errs[name] = fmt.Errorf("failed to evaluate condition %s: %w", resource.Condition, err)
+			continue
+		}
+		if !enabled {
+			continue
+		}
+
+		properties, err := ev.Evaluate(ctx, resource.Properties)
+		if err != nil {
+			errs[name] = fmt.Errorf("failed to evaluate properties: %w", err)
+			continue
+		}
+		resource.Properties, _ = properties.(map[string]any)
+		evaluated.Resources[name] = resource
+	}
+
+	return &evaluated, errs
+}
+
+// Evaluate returns the value with every intrinsic function that can be
+// resolved replaced by its result.
+func (ev *Evaluator) Evaluate(ctx context.Context, value any) (any, error) {
+	evaluated, _, err := ev.evaluate(ctx, value)
+	return evaluated, err
+}
+
+// evaluate reports whether the value was resolved completely. Functions that
+// cannot be resolved are returned unchanged.
+func (ev *Evaluator) evaluate(ctx context.Context, value any) (any, bool, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 1 {
+			for name, args := range v {
+				if name == "Ref" || strings.HasPrefix(name, "Fn::") {
+					result, ok, err := ev.evaluateFunction(ctx, name, args)
+					if err != nil || !ok {
+						return value, false, err
+					}
+					return result, true, nil
+				}
+			}
+		}
+
+		out := make(map[string]any, len(v))
+		resolved := true
+		for key, item := range v {
+			evaluated, ok, err := ev.evaluate(ctx, item)
+			if err != nil {
+				return nil, false, err
+			}
+			if evaluated == noValue {
+				continue
+			}
+			out[key] = evaluated
+			resolved = resolved && ok
+		}
+		return out, resolved, nil
+	case []any:
+		out := make([]any, 0, len(v))
+		resolved := true
+		for _, item := range v {
+			evaluated, ok, err := ev.evaluate(ctx, item)
+			if err != nil {
+				return nil, false, err
+			}
+			if evaluated == noValue {
+				continue
+			}
+			out = append(out, evaluated)
+			resolved = resolved && ok
+		}
+		return out, resolved, nil
+	}
+
+	return value, true, nil
+}
+
+func (ev *Evaluator) evaluateFunction(ctx context.Context, name string, args any) (any, bool, error) {
+	switch name {
+	case "Ref":
+		return ev.ref(ctx, args)
+	case "Fn::Sub":
+		return ev.sub(ctx, args)
+	case "Fn::Join":
+		return ev.join(ctx, args)
+	case "Fn::Select":
+		return ev.selectItem(ctx, args)
+	case "Fn::Split":
+		return ev.split(ctx, args)
+	case "Fn::If":
+		return ev.ifCondition(ctx, args)
+	case "Fn::FindInMap":
+		return ev.findInMap(ctx, args)
+	}
+
+	return nil, false, nil
+}
+
+func (ev *Evaluator) ref(ctx context.Context, args any) (any, bool, error) {
+	name, ok := args.(string)
+	if !ok {
+		return nil, false, nil
+	}
+	if name == "AWS::NoValue" {
+		return noValue, true, nil
+	}
+
+	value, ok, err := ev.pseudo.lookup(ctx, name)
+	if err != nil || ok {
+		return value, ok, err
+	}
+
+	return ev.parameter(name)
+}
+
+// parameter returns the supplied or default value of a template parameter.
+// List parameters evaluate to a list of strings. The default of an SSM
+// parameter type is the name of the SSM parameter rather than its value, so
+// those only resolve through a supplied value.
+func (ev *Evaluator) parameter(name string) (any, bool, error) {
+	param, ok := ev.Template.Parameters[name]
+	if !ok || ev.Unknown[name] {
+		return nil, false, nil
+	}
+
+	value, ok := ev.Parameters[name]
+	if !ok {
+		if param.Default == nil || IsSSMParameterType(param.Type) {
+			return nil, false, nil
+		}
+		value = fmt.Sprint(param.Default)
+	}
+
+	if param.Type == "CommaDelimitedList" || strings.Contains(param.Type, "List<") {
+		var items []any
+		for _, item := range strings.Split(value, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+		return items, true, nil
+	}
+
+	return value, true, nil
+}
+
+func (ev *Evaluator) sub(ctx context.Context, args any) (any, bool, error) {
+	var template string
+	variables := make(map[string]string)
+
+	switch a := args.(type) {
+	case string:
+		template = a
+	case []any:
+		if len(a) != 2 {
+			return nil, false, nil
+		}
+		s, ok := a[0].(string)
+		if !ok {
+			return nil, false, nil
+		}
+		template = s
+
+		m, ok := a[1].(map[string]any)
+		if !ok {
+			return nil, false, nil
+		}
+		for name, value := range m {
+			evaluated, ok, err := ev.evaluate(ctx, value)
+			if err != nil || !ok {
+				return nil, false, err
+			}
+			s, ok := scalarString(evaluated)
+			if !ok {
+				return nil, false, nil
+			}
+			variables[name] = s
+		}
+	default:
+		return nil, false, nil
+	}
+
+	return substitute(template, func(name string) (string, bool, error) {
+		if value, ok := variables[name]; ok {
+			return value, true, nil
+		}
+		if strings.Contains(name, ".") {
+			// ${Resource.Attribute} is an Fn::GetAtt.
+			return "", false, nil
+		}
+
+		value, ok, err := ev.ref(ctx, name)
+		if err != nil || !ok {
+			return "", false, err
+		}
+		s, ok := scalarString(value)
+		return s, ok, nil
+	})
+}
+
+// substitute expands the ${} variables of an Fn::Sub string, reporting false
+// when any variable cannot be resolved.
+func substitute(s string, lookup func(name string) (string, bool, error)) (string, bool, error) {
+	var sb strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			sb.WriteString(s)
+			return sb.String(), true, nil
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			sb.WriteString(s)
+			return sb.String(), true, nil
+		}
+
+		sb.WriteString(s[:start])
+		variable := s[start+2 : start+end]
+		if strings.HasPrefix(variable, "!") {
+			sb.WriteString("${" + variable[1:] + "}")
+		} else {
+			value, ok, err := lookup(strings.TrimSpace(variable))
+			if err != nil || !ok {
+				return "", false, err
+			}
+			sb.WriteString(value)
+		}
+		s = s[start+end+1:]
+	}
+}
+
+func (ev *Evaluator) join(ctx context.Context, args any) (any, bool, error) {
+	a, ok := args.([]any)
+	if !ok || len(a) != 2 {
+		return nil, false, nil
+	}
+	delimiter, ok := a[0].(string)
+	if !ok {
+		return nil, false, nil
+	}
+
+	items, ok, err := ev.evaluateList(ctx, a[1])
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	parts := make([]string, len(items))
+	for i, item := range items {
+		s, ok := scalarString(item)
+		if !ok {
+			return nil, false, nil
+		}
+		parts[i] = s
+	}
+
+	return strings.Join(parts, delimiter), true, nil
+}
+
+func (ev *Evaluator) selectItem(ctx context.Context, args any) (any, bool, error) {
+	a, ok := args.([]any)
+	if !ok || len(a) != 2 {
+		return nil, false, nil
+	}
+
+	index, ok, err := ev.evaluate(ctx, a[0])
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	s, ok := scalarString(index)
+	if !ok {
+		return nil, false, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid Fn::Select index %q", s)
+	}
+
+	items, ok, err := ev.evaluateList(ctx, a[1])
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	if i < 0 || i >= len(items) {
+		return nil, false, fmt.Errorf("Fn::Select index %d out of range", i)
+	}
+
+	return items[i], true, nil
+}
+
+func (ev *Evaluator) split(ctx context.Context, args any) (any, bool, error) {
+	a, ok := args.([]any)
+	if !ok || len(a) != 2 {
+		return nil, false, nil
+	}
+	delimiter, ok := a[0].(string)
+	if !ok {
+		return nil, false, nil
+	}
+
+	source, ok, err := ev.evaluate(ctx, a[1])
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	s, ok := source.(string)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var items []any
+	for _, item := range strings.Split(s, delimiter) {
+		items = append(items, item)
+	}
+
+	return items, true, nil
+}
+
+func (ev *Evaluator) ifCondition(ctx context.Context, args any) (any, bool, error) {
+	a, ok := args.([]any)
+	if !ok || len(a) != 3 {
+		return nil, false, nil
+	}
+	name, ok := a[0].(string)
+	if !ok {
+		return nil, false, nil
+	}
+
+	value, known, err := ev.condition(ctx, name)
+	if err != nil || !known {
+		return nil, false, err
+	}
+	if value {
+		return ev.evaluate(ctx, a[1])
+	}
+	return ev.evaluate(ctx, a[2])
+}
+
+// findInMap looks up a value in the Mappings section. The optional fourth
+// argument is the DefaultValue of the AWS::LanguageExtensions transform,
+// used when the keys are not in the mapping.
+func (ev *Evaluator) findInMap(ctx context.Context, args any) (any, bool, error) {
+	a, ok := args.([]any)
+	if !ok || (len(a) != 3 && len(a) != 4) {
+		return nil, false, nil
+	}
+
+	var keys [3]string
+	for i := range keys {
+		evaluated, ok, err := ev.evaluate(ctx, a[i])
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		s, ok := scalarString(evaluated)
+		if !ok {
+			return nil, false, nil
+		}
+		keys[i] = s
+	}
+
+	if value, ok := ev.Template.Mappings[keys[0]][keys[1]][keys[2]]; ok {
+		return ev.evaluate(ctx, value)
+	}
+
+	if len(a) == 4 {
+		options, ok := a[3].(map[string]any)
+		if !ok {
+			return nil, false, nil
+		}
+		if defaultValue, ok := options["DefaultValue"]; ok {
+			return ev.evaluate(ctx, defaultValue)
+		}
+	}
+
+	return nil, false, fmt.Errorf("Fn::FindInMap key %s.%s.%s not found in mappings", keys[0], keys[1], keys[2])
+}
+
+func (ev *Evaluator) evaluateList(ctx context.Context, value any) ([]any, bool, error) {
+	evaluated, ok, err := ev.evaluate(ctx, value)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	items, ok := evaluated.([]any)
+	return items, ok, nil
+}
+
+func scalarString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int, int64, float64, bool:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
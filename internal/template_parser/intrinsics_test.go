@@ -0,0 +1,173 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"reflect"
+	"testing"
+)
+
+const evaluatorTemplate = `
+Parameters:
+  Env:
+    Type: String
+    Default: prod
+  Name:
+    Type: String
+  Subnets:
+    Type: CommaDelimitedList
+    Default: "a, b"
+  Unset:
+    Type: String
+  SSMName:
+    Type: AWS::SSM::Parameter::Value<String>
+    Default: /org/bucket-name
+  SSMResolved:
+    Type: AWS::SSM::Parameter::Value<String>
+    Default: /org/queue-name
+  SSMList:
+    Type: AWS::SSM::Parameter::Value<List<String>>
+Mappings:
+  Regions:
+    us-east-1:
+      Ami: ami-1
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+  IsUnset: !Equals [!Ref Unset, x]
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+`
+
+// newTestEvaluator returns an evaluator for a template in us-east-1. The
+// account ID is not set, so tests must not reference AWS::AccountId.
+func newTestEvaluator(t *testing.T, body string, parameters map[string]string) *Evaluator {
+	t.Helper()
+
+	var template types.CloudFormationTemplate
+	if _, _, err := unmarshalTemplate([]byte(body), &template); err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+
+	return newEvaluator(&template, parameters, newPseudoParameters(aws.Config{Region: "us-east-1"}, "my-stack"))
+}
+
+func parseYAMLValue(t *testing.T, value string) any {
+	t.Helper()
+
+	var out any
+	if _, err := unmarshalYAML([]byte(value), &out); err != nil {
+		t.Fatalf("failed to parse %q: %v", value, err)
+	}
+	return out
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "ref parameter", value: `!Ref Name`, want: `app`},
+		{name: "ref parameter default", value: `!Ref Env`, want: `prod`},
+		{name: "ref list parameter", value: `!Ref Subnets`, want: `[a, b]`},
+		{name: "ref region", value: `!Ref AWS::Region`, want: `us-east-1`},
+		{name: "ref partition", value: `!Ref AWS::Partition`, want: `aws`},
+		{name: "ref stack name", value: `!Ref AWS::StackName`, want: `my-stack`},
+		{name: "unresolved ref to parameter without value", value: `!Ref Unset`, want: `!Ref Unset`},
+		{name: "unresolved ssm parameter default", value: `!Ref SSMName`, want: `!Ref SSMName`},
+		{name: "resolved ssm parameter", value: `!Ref SSMResolved`, want: `queue`},
+		{name: "resolved ssm list parameter", value: `!Ref SSMList`, want: `[x, y]`},
+		{name: "unresolved ref to resource", value: `!Ref Bucket`, want: `!Ref Bucket`},
+		{name: "no value in list", value: `[a, !Ref AWS::NoValue, b]`, want: `[a, b]`},
+		{name: "no value in map", value: `{A: x, B: !Ref AWS::NoValue}`, want: `{A: x}`},
+		{name: "no value from if", value: `[a, !If [IsProd, !Ref AWS::NoValue, b]]`, want: `[a]`},
+		{name: "sub", value: `!Sub "${Name}-${AWS::Region}"`, want: `app-us-east-1`},
+		{name: "sub with variables", value: `!Sub ["${Prefix}-${Name}", {Prefix: !Ref Env}]`, want: `prod-app`},
+		{name: "sub literal", value: `!Sub "${!Literal}-${Name}"`, want: `${Literal}-app`},
+		{name: "sub get attribute", value: `!Sub "${Bucket.Arn}"`, want: `!Sub "${Bucket.Arn}"`},
+		{name: "join", value: `!Join ["-", [a, !Ref Name]]`, want: `a-app`},
+		{name: "join unresolved", value: `!Join ["-", [a, !Ref Bucket]]`, want: `!Join ["-", [a, !Ref Bucket]]`},
+		{name: "select split", value: `!Select [1, !Split [",", "x,y,z"]]`, want: `y`},
+		{name: "select out of range", value: `!Select [3, [a, b]]`, wantErr: true},
+		{name: "if true", value: `!If [IsProd, big, small]`, want: `big`},
+		{name: "if unknown", value: `!If [IsUnset, a, b]`, want: `!If [IsUnset, a, b]`},
+		{name: "find in map", value: `!FindInMap [Regions, !Ref AWS::Region, Ami]`, want: `ami-1`},
+		{name: "find in map default", value: `!FindInMap [Regions, eu-west-1, Ami, {DefaultValue: ami-0}]`, want: `ami-0`},
+		{name: "find in map missing", value: `!FindInMap [Regions, eu-west-1, Ami]`, wantErr: true},
+		{name: "nested short form", value: `!Join ["", [!Sub "${Name}-", !Select [0, !Ref Subnets]]]`, want: `app-a`},
+		{name: "long form", value: `{"Fn::Join": ["-", [{"Ref": "Env"}, x]]}`, want: `prod-x`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev := newTestEvaluator(t, evaluatorTemplate, map[string]string{"Name": "app", "SSMResolved": "queue", "SSMList": "x,y"})
+
+			got, err := ev.Evaluate(context.Background(), parseYAMLValue(t, tt.value))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Evaluate(%s) = %v, want error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate(%s) failed: %v", tt.value, err)
+			}
+
+			want := parseYAMLValue(t, tt.want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Evaluate(%s) = %#v, want %#v", tt.value, got, want)
+			}
+		})
+	}
+}
+
+func TestEvaluateUnknownParameter(t *testing.T) {
+	ev := newTestEvaluator(t, evaluatorTemplate, map[string]string{"Name": "app"})
+	ev.Unknown = map[string]bool{"Env": true}
+
+	value := parseYAMLValue(t, `!Sub "${Env}-${Name}"`)
+	got, err := ev.Evaluate(context.Background(), value)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, value) {
+		t.Errorf("Evaluate = %#v, want it unresolved instead of using the default", got)
+	}
+}
+
+func TestEvaluateTemplate(t *testing.T) {
+	ev := newTestEvaluator(t, `
+Conditions:
+  Never: !Equals [a, b]
+Resources:
+  Queue:
+    Type: AWS::SQS::Queue
+    Properties:
+      QueueName: !Sub "${AWS::Region}-queue"
+  Skipped:
+    Type: AWS::SQS::Queue
+    Condition: Never
+  Broken:
+    Type: AWS::SQS::Queue
+    Properties:
+      QueueName: !FindInMap [Missing, a, b]
+`, nil)
+
+	evaluated, errs := ev.EvaluateTemplate(context.Background())
+
+	if got := evaluated.Resources["Queue"].Properties["QueueName"]; got != "us-east-1-queue" {
+		t.Errorf("QueueName = %v, want us-east-1-queue", got)
+	}
+	if _, ok := evaluated.Resources["Skipped"]; ok {
+		t.Error("resource with a false condition was not left out")
+	}
+	if _, ok := evaluated.Resources["Broken"]; ok {
+		t.Error("resource that failed to evaluate was not left out")
+	}
+	if len(errs) != 1 || errs["Broken"] == nil {
+		t.Errorf("errors = %v, want only Broken", errs)
+	}
+}
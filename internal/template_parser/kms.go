@@ -0,0 +1,77 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_kms"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::KMS::Key", parserMethod(newKMSParser, (*KMSParser).parseKey))
+	RegisterResolver("AWS::KMS::Alias", parserMethod(newKMSParser, (*KMSParser).parseAlias))
+}
+
+type KMSParser struct {
+	KMSClient *aws_kms.AWSClient
+}
+
+func newKMSParser(cfg aws.Config) *KMSParser {
+	return &KMSParser{
+		KMSClient: &aws_kms.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+// parseKey resolves a key through the aliases in the template that target
+// it, as keys have no name of their own.
+func (kp *KMSParser) parseKey(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	var unresolved error
+	for _, name := range sortedResourceNames(req.Template.Resources) {
+		alias := req.Template.Resources[name]
+		if alias.Type != "AWS::KMS::Alias" || !referencesResource(alias.Properties["TargetKeyId"], req.LogicalId) {
+			continue
+		}
+
+		aliasName, ok, err := stringProperty(alias, "AliasName")
+		if err != nil {
+			unresolved = err
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		keyId, err := kp.KMSClient.FindAliasTargetKeyId(ctx, aliasName)
+		if err != nil {
+			return nil, err
+		}
+		if keyId != nil {
+			return newResourceToImport(req, map[string]string{
+				"KeyId": *keyId,
+			}), nil
+		}
+	}
+
+	return nil, unresolved
+}
+
+func (kp *KMSParser) parseAlias(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	aliasName, ok, err := stringProperty(req.Resource, "AliasName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	keyId, err := kp.KMSClient.FindAliasTargetKeyId(ctx, aliasName)
+	if err != nil {
+		return nil, err
+	}
+	if keyId == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"AliasName": aliasName,
+	}), nil
+}
@@ -0,0 +1,232 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_lambda"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"strings"
+)
+
+func init() {
+	RegisterResolver("AWS::Lambda::Function", parserMethod(newLambdaParser, (*LambdaParser).parseFunction))
+	RegisterResolver("AWS::Lambda::Alias", parserMethod(newLambdaParser, (*LambdaParser).parseAlias))
+	RegisterResolver("AWS::Lambda::Permission", parserMethod(newLambdaParser, (*LambdaParser).parsePermission))
+	RegisterResolver("AWS::Lambda::LayerVersion", parserMethod(newLambdaParser, (*LambdaParser).parseLayerVersion))
+}
+
+type LambdaParser struct {
+	LambdaClient *aws_lambda.AWSClient
+}
+
+func newLambdaParser(cfg aws.Config) *LambdaParser {
+	return &LambdaParser{
+		LambdaClient: &aws_lambda.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (lp *LambdaParser) parseFunction(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	functionName, ok, err := stringProperty(req.Resource, "FunctionName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	name, err := lp.LambdaClient.GetFunctionName(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	if name == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"FunctionName": *name,
+	}), nil
+}
+
+func (lp *LambdaParser) parseAlias(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	functionName, ok, err := functionNameProperty(req)
+	if err != nil || !ok {
+		return nil, err
+	}
+	aliasName, ok, err := stringProperty(req.Resource, "Name")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	arn, err := lp.LambdaClient.GetAliasArn(ctx, functionName, aliasName)
+	if err != nil {
+		return nil, err
+	}
+	if arn == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"AliasArn": *arn,
+	}), nil
+}
+
+type lambdaPolicy struct {
+	Statement []struct {
+		Sid       string         `json:"Sid"`
+		Action    any            `json:"Action"`
+		Principal any            `json:"Principal"`
+		Condition map[string]any `json:"Condition"`
+	} `json:"Statement"`
+}
+
+// permissionConditions maps the properties of a permission to the policy
+// condition keys Lambda stores them under.
+var permissionConditions = map[string]string{
+	"SourceArn":           "AWS:SourceArn",
+	"SourceAccount":       "AWS:SourceAccount",
+	"EventSourceToken":    "lambda:EventSourceToken",
+	"PrincipalOrgID":      "aws:PrincipalOrgID",
+	"FunctionUrlAuthType": "lambda:FunctionUrlAuthType",
+}
+
+// parsePermission finds the policy statement CloudFormation created for the
+// permission. The statement ID is generated, so it is matched on the action,
+// the principal and every condition the template sets instead. A statement
+// with conditions the template does not set belongs to another permission.
+func (lp *LambdaParser) parsePermission(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	functionName, ok, err := functionNameProperty(req)
+	if err != nil || !ok {
+		return nil, err
+	}
+	action, ok, err := stringProperty(req.Resource, "Action")
+	if err != nil || !ok {
+		return nil, err
+	}
+	principal, ok, err := stringProperty(req.Resource, "Principal")
+	if err != nil || !ok {
+		return nil, err
+	}
+	conditions := make(map[string]string)
+	for property, key := range permissionConditions {
+		value, ok, err := stringProperty(req.Resource, property)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			conditions[key] = value
+		}
+	}
+
+	document, err := lp.LambdaClient.GetFunctionPolicy(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, nil
+	}
+
+	var policy lambdaPolicy
+	if err := json.Unmarshal([]byte(*document), &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy of %s: %w", functionName, err)
+	}
+
+	var matches []string
+	for _, statement := range policy.Statement {
+		if !policyValueMatches(statement.Action, action) || !policyValueMatches(statement.Principal, principal) {
+			continue
+		}
+		if !policyConditionsMatch(statement.Condition, conditions) {
+			continue
+		}
+		matches = append(matches, statement.Sid)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return newResourceToImport(req, map[string]string{
+			"FunctionName": functionName,
+			"Id":           matches[0],
+		}), nil
+	default:
+		return nil, fmt.Errorf("policy of %s has several statements matching the permission: %s", functionName, strings.Join(matches, ", "))
+	}
+}
+
+// parseLayerVersion reports layer versions as unsupported. A version is
+// identified by its number, which CloudFormation assigns on publish and the
+// template does not contain, so there is no telling which version a template
+// created.
+func (lp *LambdaParser) parseLayerVersion(_ context.Context, _ *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	return nil, fmt.Errorf("%w: layer versions cannot be matched to a template", ErrUnsupportedResourceType)
+}
+
+// functionNameProperty resolves the FunctionName property of resources that
+// attach to a function. It may be a name, an ARN, or a Ref or Fn::GetAtt to
+// a function in the same template.
+func functionNameProperty(req *ResolveRequest) (string, bool, error) {
+	value := req.Resource.Properties["FunctionName"]
+	if m, ok := value.(map[string]any); ok {
+		if getAtt, ok := m["Fn::GetAtt"].([]any); ok && len(getAtt) == 2 {
+			value = map[string]any{"Ref": getAtt[0]}
+		}
+	}
+
+	return refProperty(req.Template, value, "FunctionName")
+}
+
+// policyValueMatches reports whether a policy element contains the expected
+// value. Principals are matched on the account ID inside an ARN as well,
+// since Lambda expands account principals to root ARNs.
+func policyValueMatches(element any, expected string) bool {
+	switch v := element.(type) {
+	case string:
+		return v == expected || strings.Contains(v, ":"+expected+":")
+	case []any:
+		for _, item := range v {
+			if policyValueMatches(item, expected) {
+				return true
+			}
+		}
+	case map[string]any:
+		for _, item := range v {
+			if policyValueMatches(item, expected) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// policyConditionsMatch reports whether a statement's conditions are exactly
+// the expected condition keys and values, whichever operator they use.
+func policyConditionsMatch(condition map[string]any, expected map[string]string) bool {
+	found := make(map[string]string)
+	for _, operator := range condition {
+		values, ok := operator.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, value := range values {
+			s, ok := value.(string)
+			if !ok {
+				return false
+			}
+			found[strings.ToLower(key)] = s
+		}
+	}
+
+	if len(found) != len(expected) {
+		return false
+	}
+	for key, value := range expected {
+		if found[strings.ToLower(key)] != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_cloudwatchlogs"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::Logs::LogGroup", parserMethod(newLogsParser, (*LogsParser).parseLogGroup))
+	RegisterResolver("AWS::Logs::MetricFilter", parserMethod(newLogsParser, (*LogsParser).parseMetricFilter))
+	RegisterResolver("AWS::Logs::SubscriptionFilter", parserMethod(newLogsParser, (*LogsParser).parseSubscriptionFilter))
+}
+
+type LogsParser struct {
+	LogsClient *aws_cloudwatchlogs.AWSClient
+}
+
+func newLogsParser(cfg aws.Config) *LogsParser {
+	return &LogsParser{
+		LogsClient: &aws_cloudwatchlogs.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (lp *LogsParser) parseLogGroup(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	logGroupName, ok, err := stringProperty(req.Resource, "LogGroupName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	exists, err := lp.LogsClient.LogGroupExists(ctx, logGroupName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"LogGroupName": logGroupName,
+	}), nil
+}
+
+func (lp *LogsParser) parseMetricFilter(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	logGroupName, filterName, ok, err := logFilterNames(req)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	exists, err := lp.LogsClient.MetricFilterExists(ctx, logGroupName, filterName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"LogGroupName": logGroupName,
+		"FilterName":   filterName,
+	}), nil
+}
+
+func (lp *LogsParser) parseSubscriptionFilter(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	logGroupName, filterName, ok, err := logFilterNames(req)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	exists, err := lp.LogsClient.SubscriptionFilterExists(ctx, logGroupName, filterName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"FilterName":   filterName,
+		"LogGroupName": logGroupName,
+	}), nil
+}
+
+// logFilterNames returns the log group and filter name of a metric or
+// subscription filter. The log group is usually a Ref to a log group in the
+// same template.
+func logFilterNames(req *ResolveRequest) (string, string, bool, error) {
+	logGroupName, ok, err := refProperty(req.Template, req.Resource.Properties["LogGroupName"], "LogGroupName")
+	if err != nil || !ok {
+		return "", "", false, err
+	}
+	filterName, ok, err := stringProperty(req.Resource, "FilterName")
+	if err != nil || !ok {
+		return "", "", false, err
+	}
+
+	return logGroupName, filterName, true, nil
+}
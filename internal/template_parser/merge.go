@@ -0,0 +1,73 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// mergeTemplates adds the imported resources, and the parameters, rules,
+// mappings and conditions they use, to the template of an existing stack.
+// The existing resources, outputs and template settings are kept as they
+// are, because an import change set cannot modify them. Resources the
+// existing stack already has are expected to be left out of imported.
+func mergeTemplates(existing *types.CloudFormationTemplate, imported *types.CloudFormationTemplate) error {
+	var collisions []string
+	for name := range imported.Resources {
+		if _, ok := existing.Resources[name]; ok {
+			collisions = append(collisions, name)
+		}
+	}
+	if len(collisions) > 0 {
+		sort.Strings(collisions)
+		return fmt.Errorf("logical IDs already used by the existing stack: %s", strings.Join(collisions, ", "))
+	}
+
+	if existing.Resources == nil {
+		existing.Resources = make(map[string]types.Resource)
+	}
+	for name, resource := range imported.Resources {
+		existing.Resources[name] = resource
+	}
+
+	var err error
+	if existing.Parameters, err = mergeSection("parameter", existing.Parameters, imported.Parameters); err != nil {
+		return err
+	}
+	if existing.Rules, err = mergeSection("rule", existing.Rules, imported.Rules); err != nil {
+		return err
+	}
+	if existing.Mappings, err = mergeSection("mapping", existing.Mappings, imported.Mappings); err != nil {
+		return err
+	}
+	if existing.Conditions, err = mergeSection("condition", existing.Conditions, imported.Conditions); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// mergeSection adds the entries of imported to existing. An entry defined
+// in both must be identical.
+func mergeSection[V any](kind string, existing, imported map[string]V) (map[string]V, error) {
+	if len(imported) == 0 {
+		return existing, nil
+	}
+	if existing == nil {
+		existing = make(map[string]V, len(imported))
+	}
+
+	for name, value := range imported {
+		if current, ok := existing[name]; ok {
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("%s %s is defined differently in the existing stack", kind, name)
+			}
+			continue
+		}
+		existing[name] = value
+	}
+
+	return existing, nil
+}
@@ -0,0 +1,76 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"fmt"
+	"strings"
+)
+
+// stringProperty returns a resource property that is set to a plain string.
+// The second result is false when the property is not set. A property that
+// is set to something else, such as an intrinsic function that could not be
+// evaluated, is an error: the resource exists under a name that is unknown.
+func stringProperty(resource types.Resource, name string) (string, bool, error) {
+	value, ok := resource.Properties[name]
+	if !ok {
+		return "", false, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", false, unresolvedProperty(name)
+	}
+	return s, s != "", nil
+}
+
+// refProperty resolves a value that is either a plain string or a Ref to
+// another resource in the template. For a Ref the named property of the
+// referenced resource is returned, which is how resources whose physical ID
+// is their name are referenced.
+func refProperty(template *types.CloudFormationTemplate, value any, property string) (string, bool, error) {
+	if value == nil {
+		return "", false, nil
+	}
+	if s, ok := value.(string); ok {
+		return s, s != "", nil
+	}
+
+	m, ok := value.(map[string]any)
+	if !ok || len(m) != 1 {
+		return "", false, unresolvedProperty(property)
+	}
+	ref, ok := m["Ref"].(string)
+	if !ok {
+		return "", false, unresolvedProperty(property)
+	}
+	resource, ok := template.Resources[ref]
+	if !ok {
+		return "", false, unresolvedProperty(property)
+	}
+
+	return stringProperty(resource, property)
+}
+
+func unresolvedProperty(name string) error {
+	return fmt.Errorf("%w: could not resolve %s", ErrUnsupportedResourceType, name)
+}
+
+// referencesResource reports whether a value is a Ref or Fn::GetAtt to the
+// given logical ID.
+func referencesResource(value any, logicalId string) bool {
+	m, ok := value.(map[string]any)
+	if !ok || len(m) != 1 {
+		return false
+	}
+	if ref, ok := m["Ref"].(string); ok {
+		return ref == logicalId
+	}
+	switch getAtt := m["Fn::GetAtt"].(type) {
+	case []any:
+		return len(getAtt) == 2 && getAtt[0] == logicalId
+	case string:
+		return strings.HasPrefix(getAtt, logicalId+".")
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"strings"
+)
+
+// pruneTemplate removes the parameters, rules, mappings and conditions that
+// the resources left in the template no longer use. Outputs are removed
+// altogether: CloudFormation rejects import change sets that add outputs, so
+// they can only be added by a stack update once the import is done.
+func pruneTemplate(template *types.CloudFormationTemplate) {
+	refs := newTemplateReferences()
+	for _, resource := range template.Resources {
+		refs.addResource(resource)
+	}
+
+	// Conditions can use other conditions, parameters and mappings.
+	visited := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for name := range refs.conditions {
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+			refs.add(template.Conditions[name])
+			changed = true
+		}
+	}
+
+	parameters := make(map[string]types.Parameter)
+	for name, parameter := range template.Parameters {
+		if refs.names[name] {
+			parameters[name] = parameter
+		}
+	}
+
+	mappings := make(map[string]map[string]map[string]interface{})
+	for name, mapping := range template.Mappings {
+		if refs.mappings[name] {
+			mappings[name] = mapping
+		}
+	}
+
+	conditions := make(map[string]interface{})
+	for name, condition := range template.Conditions {
+		if refs.conditions[name] {
+			conditions[name] = condition
+		}
+	}
+
+	rules := make(map[string]interface{})
+	for name, rule := range template.Rules {
+		ruleRefs := newTemplateReferences()
+		ruleRefs.add(rule)
+		keep := true
+		for parameter := range ruleRefs.names {
+			if _, ok := parameters[parameter]; !ok && !strings.HasPrefix(parameter, "AWS::") {
+				keep = false
+			}
+		}
+		if keep {
+			rules[name] = rule
+		}
+	}
+
+	template.Parameters = parameters
+	template.Rules = rules
+	template.Mappings = mappings
+	template.Conditions = conditions
+	template.Outputs = nil
+}
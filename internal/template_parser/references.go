@@ -0,0 +1,111 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"regexp"
+	"strings"
+)
+
+var subVariablePattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// templateReferences collects what a part of a template refers to. Names
+// holds the targets of Ref, Fn::GetAtt and Fn::Sub, which may be resources,
+// parameters or pseudo parameters.
+type templateReferences struct {
+	names      map[string]bool
+	conditions map[string]bool
+	mappings   map[string]bool
+}
+
+func newTemplateReferences() *templateReferences {
+	return &templateReferences{
+		names:      make(map[string]bool),
+		conditions: make(map[string]bool),
+		mappings:   make(map[string]bool),
+	}
+}
+
+func (refs *templateReferences) addResource(resource types.Resource) {
+	if resource.Condition != "" {
+		refs.conditions[resource.Condition] = true
+	}
+	refs.add(resource.Properties)
+	refs.add(resource.Metadata)
+	refs.add(resource.CreationPolicy)
+	refs.add(resource.UpdatePolicy)
+}
+
+func (refs *templateReferences) add(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 1 {
+			for name, args := range v {
+				refs.addFunction(name, args)
+			}
+		}
+		for _, item := range v {
+			refs.add(item)
+		}
+	case []any:
+		for _, item := range v {
+			refs.add(item)
+		}
+	}
+}
+
+func (refs *templateReferences) addFunction(name string, args any) {
+	a, _ := args.([]any)
+
+	switch name {
+	case "Ref":
+		if s, ok := args.(string); ok {
+			refs.names[s] = true
+		}
+	case "Fn::GetAtt":
+		if s, ok := args.(string); ok {
+			resource, _, _ := strings.Cut(s, ".")
+			refs.names[resource] = true
+		} else if len(a) > 0 {
+			if s, ok := a[0].(string); ok {
+				refs.names[s] = true
+			}
+		}
+	case "Fn::Sub":
+		if s, ok := args.(string); ok {
+			refs.addSubVariables(s, nil)
+		} else if len(a) == 2 {
+			s, _ := a[0].(string)
+			variables, _ := a[1].(map[string]any)
+			refs.addSubVariables(s, variables)
+		}
+	case "Fn::If", "Fn::FindInMap":
+		if len(a) == 0 {
+			return
+		}
+		if s, ok := a[0].(string); ok {
+			if name == "Fn::If" {
+				refs.conditions[s] = true
+			} else {
+				refs.mappings[s] = true
+			}
+		}
+	case "Condition":
+		if s, ok := args.(string); ok {
+			refs.conditions[s] = true
+		}
+	}
+}
+
+func (refs *templateReferences) addSubVariables(s string, variables map[string]any) {
+	for _, match := range subVariablePattern.FindAllStringSubmatch(s, -1) {
+		variable := strings.TrimSpace(match[1])
+		if strings.HasPrefix(variable, "!") {
+			continue
+		}
+		if _, ok := variables[variable]; ok {
+			continue
+		}
+		name, _, _ := strings.Cut(variable, ".")
+		refs.names[name] = true
+	}
+}
@@ -0,0 +1,77 @@
+package template_parser
+
+import (
+	"cfimporter/internal/types"
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+// ErrUnsupportedResourceType is returned by resolvers for resources that
+// cannot be located in the target account.
+var ErrUnsupportedResourceType = errors.New("unsupported resource type")
+
+// ResolveRequest describes a single template resource that should be looked
+// up in the target account. StackName and TagFilters are used to find
+// resources that can only be located by their tags.
+type ResolveRequest struct {
+	Resource   types.Resource
+	LogicalId  string
+	Template   *types.CloudFormationTemplate
+	Config     aws.Config
+	StackName  string
+	TagFilters map[string]string
+}
+
+// ResourceResolver finds the physical resource behind a template resource.
+// A nil ResourceToImport with a nil error means the resource does not exist
+// and will be created by CloudFormation instead of imported.
+type ResourceResolver interface {
+	Resolve(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error)
+}
+
+type ResolverFunc func(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error)
+
+func (f ResolverFunc) Resolve(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	return f(ctx, req)
+}
+
+var resolvers = make(map[string]ResourceResolver)
+
+// RegisterResolver registers the resolver used for a CloudFormation resource
+// type, replacing any resolver previously registered for it.
+func RegisterResolver(resourceType string, resolver ResourceResolver) {
+	resolvers[resourceType] = resolver
+}
+
+func LookupResolver(resourceType string) (ResourceResolver, bool) {
+	resolver, ok := resolvers[resourceType]
+	return resolver, ok
+}
+
+// resolverFor returns the resolver registered for a resource type, falling
+// back to the Cloud Control resolver for everything else.
+func resolverFor(resourceType string) ResourceResolver {
+	if resolver, ok := LookupResolver(resourceType); ok {
+		return resolver
+	}
+	return fallbackResolver
+}
+
+// parserMethod adapts a parser method to a ResourceResolver. The parser is
+// created from the request's config on every call so that it always talks
+// to the account being resolved.
+func parserMethod[P any](newParser func(cfg aws.Config) P, parse func(p P, ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error)) ResourceResolver {
+	return ResolverFunc(func(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+		return parse(newParser(req.Config), ctx, req)
+	})
+}
+
+func newResourceToImport(req *ResolveRequest, identifier map[string]string) *cftypes.ResourceToImport {
+	return &cftypes.ResourceToImport{
+		ResourceType:       aws.String(req.Resource.Type),
+		LogicalResourceId:  aws.String(req.LogicalId),
+		ResourceIdentifier: identifier,
+	}
+}
@@ -0,0 +1,73 @@
+package template_parser
+
+import (
+	"errors"
+	"fmt"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+type ResourceStatus string
+
+const (
+	// ResourceFound resources exist in the account and are imported.
+	ResourceFound ResourceStatus = "found"
+	// ResourceNotFound resources do not exist and will be created by
+	// CloudFormation.
+	ResourceNotFound ResourceStatus = "not_found"
+	// ResourceUnsupported resources cannot be located in the account.
+	ResourceUnsupported ResourceStatus = "unsupported"
+	// ResourceSkipped resources are left out of the import template, either
+	// because their condition is false or because the existing stack already
+	// has them.
+	ResourceSkipped ResourceStatus = "skipped"
+	// ResourceError resources could not be looked up, were found but
+	// reference resources that are not imported, or use the logical ID of a
+	// different resource in the existing stack.
+	ResourceError ResourceStatus = "error"
+)
+
+// ResolveError is returned when looking up a resource fails, for example
+// because the request was throttled or access was denied.
+type ResolveError struct {
+	LogicalId    string
+	ResourceType string
+	Err          error
+}
+
+func (e *ResolveError) Error() string {
+	return fmt.Sprintf("failed to resolve %s (%s): %v", e.LogicalId, e.ResourceType, e.Err)
+}
+
+func (e *ResolveError) Unwrap() error {
+	return e.Err
+}
+
+// ResourceResult describes what happened to a single template resource.
+type ResourceResult struct {
+	LogicalId    string            `json:"LogicalId"`
+	ResourceType string            `json:"ResourceType"`
+	Status       ResourceStatus    `json:"Status"`
+	Identifier   map[string]string `json:"Identifier,omitempty"`
+	Reason       string            `json:"Reason,omitempty"`
+	Err          error             `json:"-"`
+}
+
+// ImportResult is the outcome of generating an import template. Results has
+// an entry for every resource of the input template, in logical ID order.
+type ImportResult struct {
+	Template          []byte
+	ResourcesToImport []cftypes.ResourceToImport
+	Results           []ResourceResult
+}
+
+// Err returns the errors of the resources that could not be looked up, or
+// nil if every lookup succeeded.
+func (r *ImportResult) Err() error {
+	var errs []error
+	for _, result := range r.Results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,113 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_route53"
+	"cfimporter/internal/types"
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::Route53::HostedZone", parserMethod(newRoute53Parser, (*Route53Parser).parseHostedZone))
+	RegisterResolver("AWS::Route53::RecordSet", parserMethod(newRoute53Parser, (*Route53Parser).parseRecordSet))
+}
+
+type Route53Parser struct {
+	Route53Client *aws_route53.AWSClient
+}
+
+func newRoute53Parser(cfg aws.Config) *Route53Parser {
+	return &Route53Parser{
+		Route53Client: &aws_route53.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (rp *Route53Parser) parseHostedZone(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	zoneId, err := rp.hostedZoneId(ctx, req.Resource)
+	if err != nil || zoneId == nil {
+		return nil, err
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"Id": *zoneId,
+	}), nil
+}
+
+// parseRecordSet checks whether a record already exists. CloudFormation
+// cannot import record sets, so an existing record is reported as
+// unsupported rather than left to fail the stack on creation.
+func (rp *Route53Parser) parseRecordSet(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	recordName, ok, err := stringProperty(req.Resource, "Name")
+	if err != nil || !ok {
+		return nil, err
+	}
+	recordType, ok, err := stringProperty(req.Resource, "Type")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	zoneId, ok, err := stringProperty(req.Resource, "HostedZoneId")
+	if err != nil || !ok {
+		zone, err := rp.recordSetZoneId(ctx, req)
+		if err != nil || zone == nil {
+			return nil, err
+		}
+		zoneId = *zone
+	}
+
+	exists, err := rp.Route53Client.RecordSetExists(ctx, zoneId, recordName, recordType)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: %s record %s already exists and record sets cannot be imported", ErrUnsupportedResourceType, recordType, recordName)
+	}
+
+	return nil, nil
+}
+
+// recordSetZoneId finds the zone of a record set declared with a
+// HostedZoneName, or with a HostedZoneId that is a Ref to a zone in the
+// same template.
+func (rp *Route53Parser) recordSetZoneId(ctx context.Context, req *ResolveRequest) (*string, error) {
+	zoneName, ok, err := stringProperty(req.Resource, "HostedZoneName")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return rp.Route53Client.FindHostedZoneId(ctx, zoneName, false)
+	}
+
+	value, ok := req.Resource.Properties["HostedZoneId"]
+	if !ok {
+		return nil, nil
+	}
+	ref, ok := value.(map[string]any)
+	if !ok {
+		return nil, unresolvedProperty("HostedZoneId")
+	}
+	logicalId, ok := ref["Ref"].(string)
+	if !ok {
+		return nil, unresolvedProperty("HostedZoneId")
+	}
+	zone, ok := req.Template.Resources[logicalId]
+	if !ok {
+		return nil, unresolvedProperty("HostedZoneId")
+	}
+
+	return rp.hostedZoneId(ctx, zone)
+}
+
+func (rp *Route53Parser) hostedZoneId(ctx context.Context, zone types.Resource) (*string, error) {
+	zoneName, ok, err := stringProperty(zone, "Name")
+	if err != nil || !ok {
+		return nil, err
+	}
+	_, private := zone.Properties["VPCs"]
+
+	return rp.Route53Client.FindHostedZoneId(ctx, zoneName, private)
+}
@@ -0,0 +1,63 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_s3"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::S3::Bucket", parserMethod(newS3Parser, (*S3Parser).parseBucket))
+	RegisterResolver("AWS::S3::BucketPolicy", parserMethod(newS3Parser, (*S3Parser).parseBucketPolicy))
+}
+
+type S3Parser struct {
+	S3Client *aws_s3.AWSClient
+}
+
+func newS3Parser(cfg aws.Config) *S3Parser {
+	return &S3Parser{
+		S3Client: &aws_s3.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (sp *S3Parser) parseBucket(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	bucketName, ok, err := stringProperty(req.Resource, "BucketName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	exists, err := sp.S3Client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"BucketName": bucketName,
+	}), nil
+}
+
+func (sp *S3Parser) parseBucketPolicy(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	bucketName, ok, err := refProperty(req.Template, req.Resource.Properties["Bucket"], "BucketName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	exists, err := sp.S3Client.BucketPolicyExists(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"Bucket": bucketName,
+	}), nil
+}
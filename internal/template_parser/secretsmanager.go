@@ -0,0 +1,45 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_secretsmanager"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::SecretsManager::Secret", parserMethod(newSecretsManagerParser, (*SecretsManagerParser).parseSecret))
+}
+
+type SecretsManagerParser struct {
+	SecretsManagerClient *aws_secretsmanager.AWSClient
+}
+
+func newSecretsManagerParser(cfg aws.Config) *SecretsManagerParser {
+	return &SecretsManagerParser{
+		SecretsManagerClient: &aws_secretsmanager.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+// parseSecret resolves the secret ARN from its name. The ARN ends in a random
+// suffix, so it cannot be derived from the template alone.
+func (sp *SecretsManagerParser) parseSecret(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	secretName, ok, err := stringProperty(req.Resource, "Name")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	arn, err := sp.SecretsManagerClient.GetSecretArn(ctx, secretName)
+	if err != nil {
+		return nil, err
+	}
+	if arn == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"Id": *arn,
+	}), nil
+}
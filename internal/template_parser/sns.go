@@ -0,0 +1,89 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_sns"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"strings"
+)
+
+func init() {
+	RegisterResolver("AWS::SNS::Topic", parserMethod(newSNSParser, (*SNSParser).parseTopic))
+	RegisterResolver("AWS::SNS::Subscription", parserMethod(newSNSParser, (*SNSParser).parseSubscription))
+}
+
+type SNSParser struct {
+	SNSClient *aws_sns.AWSClient
+}
+
+func newSNSParser(cfg aws.Config) *SNSParser {
+	return &SNSParser{
+		SNSClient: &aws_sns.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (sp *SNSParser) parseTopic(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	topicName, ok, err := stringProperty(req.Resource, "TopicName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	arn, err := sp.SNSClient.FindTopicArnByName(ctx, topicName)
+	if err != nil {
+		return nil, err
+	}
+	if arn == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"TopicArn": *arn,
+	}), nil
+}
+
+func (sp *SNSParser) parseSubscription(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	protocol, ok, err := stringProperty(req.Resource, "Protocol")
+	if err != nil || !ok {
+		return nil, err
+	}
+	endpoint, ok, err := stringProperty(req.Resource, "Endpoint")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	topicArn, err := sp.topicArn(ctx, req)
+	if err != nil || topicArn == nil {
+		return nil, err
+	}
+
+	arn, err := sp.SNSClient.FindSubscriptionArn(ctx, *topicArn, protocol, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if arn == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"Arn": *arn,
+	}), nil
+}
+
+// topicArn resolves the TopicArn property of a subscription, which is either
+// an ARN or a Ref to a topic declared in the same template.
+func (sp *SNSParser) topicArn(ctx context.Context, req *ResolveRequest) (*string, error) {
+	value := req.Resource.Properties["TopicArn"]
+	if s, ok := value.(string); ok && strings.HasPrefix(s, "arn:") {
+		return &s, nil
+	}
+
+	topicName, ok, err := refProperty(req.Template, value, "TopicName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return sp.SNSClient.FindTopicArnByName(ctx, topicName)
+}
@@ -0,0 +1,43 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_sqs"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::SQS::Queue", parserMethod(newSQSParser, (*SQSParser).parseQueue))
+}
+
+type SQSParser struct {
+	SQSClient *aws_sqs.AWSClient
+}
+
+func newSQSParser(cfg aws.Config) *SQSParser {
+	return &SQSParser{
+		SQSClient: &aws_sqs.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (sp *SQSParser) parseQueue(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	queueName, ok, err := stringProperty(req.Resource, "QueueName")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	url, err := sp.SQSClient.GetQueueUrl(ctx, queueName)
+	if err != nil {
+		return nil, err
+	}
+	if url == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"QueueUrl": *url,
+	}), nil
+}
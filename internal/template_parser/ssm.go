@@ -0,0 +1,43 @@
+package template_parser
+
+import (
+	"cfimporter/internal/aws/aws_ssm"
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+func init() {
+	RegisterResolver("AWS::SSM::Parameter", parserMethod(newSSMParser, (*SSMParser).parseParameter))
+}
+
+type SSMParser struct {
+	SSMClient *aws_ssm.AWSClient
+}
+
+func newSSMParser(cfg aws.Config) *SSMParser {
+	return &SSMParser{
+		SSMClient: &aws_ssm.AWSClient{
+			Config: cfg,
+		},
+	}
+}
+
+func (sp *SSMParser) parseParameter(ctx context.Context, req *ResolveRequest) (*cftypes.ResourceToImport, error) {
+	parameterName, ok, err := stringProperty(req.Resource, "Name")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	name, err := sp.SSMClient.GetParameterName(ctx, parameterName)
+	if err != nil {
+		return nil, err
+	}
+	if name == nil {
+		return nil, nil
+	}
+
+	return newResourceToImport(req, map[string]string{
+		"Name": *name,
+	}), nil
+}
@@ -1,81 +1,229 @@
 package template_parser
 
 import (
-	"cfimporter/internal/aws/aws_iam"
 	"cfimporter/internal/types"
 	"context"
+	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
-	"gopkg.in/yaml.v3"
+	"log"
+	"sort"
+	"strings"
 )
 
 type CFImport struct {
 	Config *aws.Config
+	// StackName is the name of the stack that originally created the
+	// resources. Resources without a name property are found through the
+	// aws:cloudformation tags it left on them.
+	StackName  string
+	TagFilters map[string]string
+	// Parameters holds the values of template parameters. Parameters that
+	// are not set use their default value.
+	Parameters map[string]string
+	// UnknownParameters are parameters that have a value which cannot be
+	// read, such as NoEcho parameters of a deployed stack. References to
+	// them are left unresolved rather than falling back to the default.
+	UnknownParameters map[string]bool
+	// OutputFormat is the format of the generated template, FormatYAML or
+	// FormatJSON. It defaults to the format of the input template, or of
+	// ExistingTemplate when it is set.
+	OutputFormat string
+	// ExistingTemplate is the template of the stack the resources are
+	// imported into, if that stack already exists. The import template is
+	// the existing template with the imported resources added to it.
+	ExistingTemplate []byte
 }
 
-func (cfi *CFImport) ParseCloudFormationImportTemplate(ctx context.Context, data []byte) ([]byte, []cftypes.ResourceToImport, error) {
-	iamParser, err := createIAMParserClient(ctx, cfi)
+// ParseCloudFormationImportTemplate looks up every resource of the template
+// in the target account and builds an import template from the ones that
+// exist. Failed lookups do not stop the import; they are reported in the
+// result with status ResourceError and returned by ImportResult.Err.
+func (cfi *CFImport) ParseCloudFormationImportTemplate(ctx context.Context, data []byte) (*ImportResult, error) {
+	cfg, err := cfi.loadConfig(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	var template types.CloudFormationTemplate
-	err = yaml.Unmarshal(data, &template)
+	format, shortForm, err := unmarshalTemplate(data, &template)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
+	var existing types.CloudFormationTemplate
+	if cfi.ExistingTemplate != nil {
+		format, shortForm, err = unmarshalTemplate(cfi.ExistingTemplate, &existing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the existing stack template: %w", err)
+		}
+	}
+
+	evaluator := newEvaluator(&template, cfi.Parameters, newPseudoParameters(cfg, cfi.StackName))
+	evaluator.Unknown = cfi.UnknownParameters
+	evaluated, evaluationErrors := evaluator.EvaluateTemplate(ctx)
+
+	var results []ResourceResult
 	var importIdentities []cftypes.ResourceToImport
 	resources := make(map[string]types.Resource)
-	for resourceName, resource := range template.Resources {
-		var identity *cftypes.ResourceToImport
-		if resource.Type == "AWS::IAM::ManagedPolicy" {
-			identity = iamParser.parseIAMPolicy(ctx, resource, resourceName)
+	for _, resourceName := range sortedResourceNames(template.Resources) {
+		resource := template.Resources[resourceName]
+		result := ResourceResult{
+			LogicalId:    resourceName,
+			ResourceType: resource.Type,
 		}
-		if resource.Type == "AWS::IAM::Role" {
-			identity = iamParser.parseIAMRole(ctx, resource, resourceName)
+
+		// Resources the existing stack already has are not looked up again.
+		if current, ok := existing.Resources[resourceName]; ok {
+			if current.Type == resource.Type {
+				result.Status = ResourceSkipped
+				result.Reason = "already in the stack"
+				log.Printf("Not importing %s: %s", resourceName, result.Reason)
+			} else {
+				err := fmt.Errorf("logical ID is used by an %s in the existing stack", current.Type)
+				result.Status = ResourceError
+				result.Err = &ResolveError{
+					LogicalId:    resourceName,
+					ResourceType: resource.Type,
+					Err:          err,
+				}
+				result.Reason = err.Error()
+				log.Printf("Not importing %s: %v", resourceName, result.Err)
+			}
+			results = append(results, result)
+			continue
 		}
-		if resource.Type == "AWS::IAM::InstanceProfile" {
-			identity = iamParser.parseInstanceProfile(ctx, resource, resourceName, template.Resources)
+		if err, ok := evaluationErrors[resourceName]; ok {
+			result.Status = ResourceError
+			result.Err = &ResolveError{
+				LogicalId:    resourceName,
+				ResourceType: resource.Type,
+				Err:          err,
+			}
+			result.Reason = err.Error()
+			log.Printf("Not importing %s: %v", resourceName, result.Err)
+			results = append(results, result)
+			continue
+		}
+		if _, ok := evaluated.Resources[resourceName]; !ok {
+			result.Status = ResourceSkipped
+			result.Reason = fmt.Sprintf("condition %s is false", resource.Condition)
+			log.Printf("Not importing %s: %s", resourceName, result.Reason)
+			results = append(results, result)
+			continue
 		}
 
-		if identity != nil {
+		identity, err := resolverFor(resource.Type).Resolve(ctx, &ResolveRequest{
+			Resource:   evaluated.Resources[resourceName],
+			LogicalId:  resourceName,
+			Template:   evaluated,
+			Config:     cfg,
+			StackName:  cfi.StackName,
+			TagFilters: cfi.TagFilters,
+		})
+		switch {
+		case errors.Is(err, ErrUnsupportedResourceType):
+			result.Status = ResourceUnsupported
+			result.Reason = err.Error()
+			log.Printf("Not importing %s: %v", resourceName, err)
+		case err != nil:
+			result.Status = ResourceError
+			result.Err = &ResolveError{
+				LogicalId:    resourceName,
+				ResourceType: resource.Type,
+				Err:          err,
+			}
+			result.Reason = err.Error()
+			log.Printf("Not importing %s: %v", resourceName, result.Err)
+		case identity == nil:
+			result.Status = ResourceNotFound
+		default:
+			log.Printf("Found %s (%s): %v", resourceName, resource.Type, identity.ResourceIdentifier)
+			result.Status = ResourceFound
+			result.Identifier = identity.ResourceIdentifier
 			importIdentities = append(importIdentities, *identity)
 			resource.DeletionPolicy = "Retain"
 			resources[resourceName] = resource
 		}
+		results = append(results, result)
 	}
 
-	importTemplate := types.CloudFormationTemplate{
-		Resources: resources,
-	}
+	graph := newDependencyGraph(&template)
+	removed := graph.removeUnresolvedDependents(resources, existing.Resources)
+	if len(removed) > 0 {
+		for i, result := range results {
+			if missing, ok := removed[result.LogicalId]; ok {
+				// The resource exists but cannot be imported, so the stack
+				// would not match the account. Report it as an error rather
+				// than a skip so callers do not carry on without it.
+				err := fmt.Errorf("depends on %s, which is not being imported", strings.Join(missing, ", "))
+				results[i].Status = ResourceError
+				results[i].Identifier = nil
+				results[i].Err = &ResolveError{
+					LogicalId:    result.LogicalId,
+					ResourceType: result.ResourceType,
+					Err:          err,
+				}
+				results[i].Reason = err.Error()
+				log.Printf("Not importing %s: %v", result.LogicalId, results[i].Err)
+			}
+		}
 
-	yamlData, err := yaml.Marshal(&importTemplate)
-	if err != nil {
-		return nil, nil, err
+		var kept []cftypes.ResourceToImport
+		for _, identity := range importIdentities {
+			if _, ok := removed[aws.ToString(identity.LogicalResourceId)]; !ok {
+				kept = append(kept, identity)
+			}
+		}
+		importIdentities = kept
+	}
+	for resourceName, resource := range resources {
+		resources[resourceName] = graph.stripDependsOn(resourceName, resource, resources, existing.Resources)
 	}
 
-	return yamlData, importIdentities, nil
-}
+	importTemplate := template
+	importTemplate.Resources = resources
+	pruneTemplate(&importTemplate)
+	if len(template.Outputs) > 0 {
+		log.Printf("Outputs are left out of the import template, update the stack with the full template once the import is done to add them")
+	}
+	if cfi.ExistingTemplate != nil {
+		if err := mergeTemplates(&existing, &importTemplate); err != nil {
+			return nil, err
+		}
+		importTemplate = existing
+	}
 
-func createIAMParserClient(ctx context.Context, cfi *CFImport) (*IAMParser, error) {
-	baseCfg, err := config.LoadDefaultConfig(ctx)
+	if cfi.OutputFormat != "" {
+		format = cfi.OutputFormat
+	}
+	templateData, err := marshalTemplate(&importTemplate, format, shortForm)
 	if err != nil {
 		return nil, err
 	}
 
+	return &ImportResult{
+		Template:          templateData,
+		ResourcesToImport: importIdentities,
+		Results:           results,
+	}, nil
+}
+
+func (cfi *CFImport) loadConfig(ctx context.Context) (aws.Config, error) {
 	if cfi.Config != nil {
-		return &IAMParser{
-			IAMClient: &aws_iam.AWSClient{
-				Config: *cfi.Config,
-			},
-		}, nil
+		return *cfi.Config, nil
 	}
 
-	return &IAMParser{
-		IAMClient: &aws_iam.AWSClient{
-			Config: baseCfg,
-		},
-	}, nil
+	return config.LoadDefaultConfig(ctx)
+}
+
+func sortedResourceNames(resources map[string]types.Resource) []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
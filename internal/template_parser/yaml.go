@@ -0,0 +1,148 @@
+package template_parser
+
+import (
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+// shortFormFunctions are the intrinsic functions with a YAML short form,
+// e.g. !Sub for Fn::Sub.
+var shortFormFunctions = map[string]bool{
+	"Ref":              true,
+	"Condition":        true,
+	"Fn::And":          true,
+	"Fn::Base64":       true,
+	"Fn::Cidr":         true,
+	"Fn::Equals":       true,
+	"Fn::FindInMap":    true,
+	"Fn::GetAZs":       true,
+	"Fn::GetAtt":       true,
+	"Fn::If":           true,
+	"Fn::ImportValue":  true,
+	"Fn::Join":         true,
+	"Fn::Length":       true,
+	"Fn::Not":          true,
+	"Fn::Or":           true,
+	"Fn::Select":       true,
+	"Fn::Split":        true,
+	"Fn::Sub":          true,
+	"Fn::ToJsonString": true,
+	"Fn::Transform":    true,
+}
+
+// unmarshalYAML decodes a YAML template, converting short-form tags to their
+// long-form mappings on the way. It reports whether any short-form tags were
+// found, so the output can be written in the same style.
+func unmarshalYAML(data []byte, out any) (bool, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return false, err
+	}
+	if document.Kind == 0 {
+		return false, nil
+	}
+
+	shortForm := expandShortForm(&document)
+
+	return shortForm, document.Decode(out)
+}
+
+// marshalYAML encodes a template, optionally writing intrinsic functions in
+// their short form.
+func marshalYAML(in any, shortForm bool) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(in); err != nil {
+		return nil, err
+	}
+
+	if shortForm {
+		collapseShortForm(&node)
+	}
+
+	return yaml.Marshal(&node)
+}
+
+func expandShortForm(node *yaml.Node) bool {
+	found := false
+	for _, child := range node.Content {
+		if expandShortForm(child) {
+			found = true
+		}
+	}
+
+	if !strings.HasPrefix(node.Tag, "!") || strings.HasPrefix(node.Tag, "!!") {
+		return found
+	}
+
+	name := strings.TrimPrefix(node.Tag, "!")
+	if name != "Ref" && name != "Condition" {
+		name = "Fn::" + name
+	}
+
+	value := *node
+	value.Tag = ""
+	switch value.Kind {
+	case yaml.MappingNode:
+		value.Tag = "!!map"
+	case yaml.SequenceNode:
+		value.Tag = "!!seq"
+	case yaml.ScalarNode:
+		// Short-form arguments are strings, including !GetAZs "".
+		value.Tag = "!!str"
+		if name == "Fn::GetAtt" {
+			// !GetAtt Resource.Attribute is [Resource, Attribute] in long form.
+			resource, attribute, _ := strings.Cut(value.Value, ".")
+			value = yaml.Node{
+				Kind: yaml.SequenceNode,
+				Tag:  "!!seq",
+				Content: []*yaml.Node{
+					{Kind: yaml.ScalarNode, Tag: "!!str", Value: resource},
+					{Kind: yaml.ScalarNode, Tag: "!!str", Value: attribute},
+				},
+			}
+		}
+	}
+
+	*node = yaml.Node{
+		Kind: yaml.MappingNode,
+		Tag:  "!!map",
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: name},
+			&value,
+		},
+		Line:   node.Line,
+		Column: node.Column,
+	}
+
+	return true
+}
+
+func collapseShortForm(node *yaml.Node) {
+	for _, child := range node.Content {
+		collapseShortForm(child)
+	}
+
+	if node.Kind != yaml.MappingNode || len(node.Content) != 2 {
+		return
+	}
+
+	name := node.Content[0].Value
+	value := node.Content[1]
+	// A condition name is plain data in most places, and a node can only
+	// carry one tag, so those stay in long form.
+	if !shortFormFunctions[name] || name == "Condition" || strings.HasPrefix(value.Tag, "!") && !strings.HasPrefix(value.Tag, "!!") {
+		return
+	}
+
+	collapsed := *value
+	if name == "Fn::GetAtt" && value.Kind == yaml.SequenceNode && len(value.Content) == 2 &&
+		value.Content[0].Kind == yaml.ScalarNode && value.Content[1].Kind == yaml.ScalarNode {
+		collapsed = yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Value: value.Content[0].Value + "." + value.Content[1].Value,
+		}
+	}
+	collapsed.Tag = "!" + strings.TrimPrefix(name, "Fn::")
+
+	*node = collapsed
+}
@@ -0,0 +1,121 @@
+package template_parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name          string
+		yaml          string
+		want          string
+		wantShortForm bool
+	}{
+		{name: "ref", yaml: `!Ref A`, want: `{"Ref": "A"}`, wantShortForm: true},
+		{name: "get attribute", yaml: `!GetAtt A.Arn`, want: `{"Fn::GetAtt": ["A", "Arn"]}`, wantShortForm: true},
+		{name: "get nested attribute", yaml: `!GetAtt A.Endpoint.Address`, want: `{"Fn::GetAtt": ["A", "Endpoint.Address"]}`, wantShortForm: true},
+		{name: "get attribute sequence", yaml: `!GetAtt [A, Arn]`, want: `{"Fn::GetAtt": ["A", "Arn"]}`, wantShortForm: true},
+		{name: "empty scalar", yaml: `!GetAZs ""`, want: `{"Fn::GetAZs": ""}`, wantShortForm: true},
+		{name: "scalar stays a string", yaml: `!Sub 123`, want: `{"Fn::Sub": "123"}`, wantShortForm: true},
+		{name: "condition", yaml: `!Condition IsProd`, want: `{"Condition": "IsProd"}`, wantShortForm: true},
+		{name: "mapping argument", yaml: `!Transform {Name: AWS::Include}`, want: `{"Fn::Transform": {"Name": "AWS::Include"}}`, wantShortForm: true},
+		{
+			name:          "nested",
+			yaml:          `!Join [",", [!Ref A, !GetAtt B.Arn, !Sub "${C}"]]`,
+			want:          `{"Fn::Join": [",", [{"Ref": "A"}, {"Fn::GetAtt": ["B", "Arn"]}, {"Fn::Sub": "${C}"}]]}`,
+			wantShortForm: true,
+		},
+		{name: "long form", yaml: `{"Fn::GetAtt": [A, Arn]}`, want: `{"Fn::GetAtt": ["A", "Arn"]}`},
+		{name: "standard tag", yaml: `!!str 123`, want: `"123"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got any
+			shortForm, err := unmarshalYAML([]byte(tt.yaml), &got)
+			if err != nil {
+				t.Fatalf("unmarshalYAML(%s) failed: %v", tt.yaml, err)
+			}
+
+			var want any
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unmarshalYAML(%s) = %#v, want %#v", tt.yaml, got, want)
+			}
+			if shortForm != tt.wantShortForm {
+				t.Errorf("unmarshalYAML(%s) short form = %v, want %v", tt.yaml, shortForm, tt.wantShortForm)
+			}
+		})
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		shortForm bool
+		want      string
+	}{
+		{name: "ref", value: `{"Ref": "A"}`, shortForm: true, want: "!Ref A\n"},
+		{name: "get attribute", value: `{"Fn::GetAtt": ["A", "Arn"]}`, shortForm: true, want: "!GetAtt A.Arn\n"},
+		{name: "empty scalar", value: `{"Fn::GetAZs": ""}`, shortForm: true, want: "!GetAZs \"\"\n"},
+		{name: "condition stays long form", value: `{"Condition": "IsProd"}`, shortForm: true, want: "Condition: IsProd\n"},
+		{name: "property", value: `{"Key": {"Fn::Sub": "${A}"}}`, shortForm: true, want: "Key: !Sub ${A}\n"},
+		{
+			name:      "nested",
+			value:     `{"Fn::If": ["C", {"Ref": "A"}, {"Ref": "AWS::NoValue"}]}`,
+			shortForm: true,
+			want:      "!If\n- C\n- !Ref A\n- !Ref AWS::NoValue\n",
+		},
+		{name: "long form", value: `{"Ref": "A"}`, want: "Ref: A\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := marshalYAML(value, tt.shortForm)
+			if err != nil {
+				t.Fatalf("marshalYAML(%s) failed: %v", tt.value, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("marshalYAML(%s) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	tests := []string{
+		"!GetAtt A.Arn\n",
+		"!GetAtt A.Endpoint.Address\n",
+		"Key: !Sub ${A}-${AWS::Region}\n",
+		"!Join\n- ','\n- - !Ref A\n  - !GetAtt B.Arn\n",
+		"!Select\n- 0\n- !GetAZs \"\"\n",
+		"!If\n- C\n- !Ref A\n- !Ref AWS::NoValue\n",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			var value any
+			if _, err := unmarshalYAML([]byte(tt), &value); err != nil {
+				t.Fatalf("unmarshalYAML failed: %v", err)
+			}
+
+			got, err := marshalYAML(value, true)
+			if err != nil {
+				t.Fatalf("marshalYAML failed: %v", err)
+			}
+			if string(got) != tt {
+				t.Errorf("round trip = %q, want %q", got, tt)
+			}
+		})
+	}
+}
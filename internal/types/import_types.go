@@ -1,11 +1,47 @@
 package types
 
 type Resource struct {
-	Type           string                 `yaml:"Type"`
-	DeletionPolicy string                 `yaml:"DeletionPolicy"`
-	Properties     map[string]interface{} `yaml:"Properties"`
+	Type                string                 `yaml:"Type" json:"Type"`
+	Condition           string                 `yaml:"Condition,omitempty" json:"Condition,omitempty"`
+	DependsOn           interface{}            `yaml:"DependsOn,omitempty" json:"DependsOn,omitempty"`
+	DeletionPolicy      string                 `yaml:"DeletionPolicy,omitempty" json:"DeletionPolicy,omitempty"`
+	UpdateReplacePolicy string                 `yaml:"UpdateReplacePolicy,omitempty" json:"UpdateReplacePolicy,omitempty"`
+	CreationPolicy      interface{}            `yaml:"CreationPolicy,omitempty" json:"CreationPolicy,omitempty"`
+	UpdatePolicy        interface{}            `yaml:"UpdatePolicy,omitempty" json:"UpdatePolicy,omitempty"`
+	Metadata            map[string]interface{} `yaml:"Metadata,omitempty" json:"Metadata,omitempty"`
+	Properties          map[string]interface{} `yaml:"Properties,omitempty" json:"Properties,omitempty"`
+}
+
+type Parameter struct {
+	Type                  string        `yaml:"Type" json:"Type"`
+	Default               interface{}   `yaml:"Default,omitempty" json:"Default,omitempty"`
+	Description           string        `yaml:"Description,omitempty" json:"Description,omitempty"`
+	AllowedValues         []interface{} `yaml:"AllowedValues,omitempty" json:"AllowedValues,omitempty"`
+	AllowedPattern        string        `yaml:"AllowedPattern,omitempty" json:"AllowedPattern,omitempty"`
+	ConstraintDescription string        `yaml:"ConstraintDescription,omitempty" json:"ConstraintDescription,omitempty"`
+	MinLength             interface{}   `yaml:"MinLength,omitempty" json:"MinLength,omitempty"`
+	MaxLength             interface{}   `yaml:"MaxLength,omitempty" json:"MaxLength,omitempty"`
+	MinValue              interface{}   `yaml:"MinValue,omitempty" json:"MinValue,omitempty"`
+	MaxValue              interface{}   `yaml:"MaxValue,omitempty" json:"MaxValue,omitempty"`
+	NoEcho                interface{}   `yaml:"NoEcho,omitempty" json:"NoEcho,omitempty"`
+}
+
+type Output struct {
+	Description interface{} `yaml:"Description,omitempty" json:"Description,omitempty"`
+	Condition   string      `yaml:"Condition,omitempty" json:"Condition,omitempty"`
+	Value       interface{} `yaml:"Value" json:"Value"`
+	Export      interface{} `yaml:"Export,omitempty" json:"Export,omitempty"`
 }
 
 type CloudFormationTemplate struct {
-	Resources map[string]Resource `yaml:"Resources"`
+	AWSTemplateFormatVersion string                                       `yaml:"AWSTemplateFormatVersion,omitempty" json:"AWSTemplateFormatVersion,omitempty"`
+	Description              interface{}                                  `yaml:"Description,omitempty" json:"Description,omitempty"`
+	Transform                interface{}                                  `yaml:"Transform,omitempty" json:"Transform,omitempty"`
+	Metadata                 map[string]interface{}                       `yaml:"Metadata,omitempty" json:"Metadata,omitempty"`
+	Parameters               map[string]Parameter                         `yaml:"Parameters,omitempty" json:"Parameters,omitempty"`
+	Rules                    map[string]interface{}                       `yaml:"Rules,omitempty" json:"Rules,omitempty"`
+	Mappings                 map[string]map[string]map[string]interface{} `yaml:"Mappings,omitempty" json:"Mappings,omitempty"`
+	Conditions               map[string]interface{}                       `yaml:"Conditions,omitempty" json:"Conditions,omitempty"`
+	Resources                map[string]Resource                          `yaml:"Resources" json:"Resources"`
+	Outputs                  map[string]Output                            `yaml:"Outputs,omitempty" json:"Outputs,omitempty"`
 }